@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"aoc25/Day1"
+	"aoc25/aoc"
+)
+
+func main() {
+	def := day1.DefaultDial()
+	size := flag.Int("size", def.Size, "number of positions on the dial")
+	start := flag.Int("start", def.Start, "starting position")
+	targets := flag.String("targets", "0", "comma-separated `positions` to count hits on")
+	trace := flag.String("trace", "", "print every rotation with its hits as a `table` or csv instead of the answers")
+	inverse := flag.String("inverse", "", "instead of solving, print a rotation list that gives `end,pass` hits on the target")
+	minimize := flag.String("minimize", "rotations", "what -inverse minimises: rotations or steps")
+	workers := flag.Int("workers", 0, "solve the input in chunks on `n` goroutines")
+	flag.Parse()
+	if *trace != "" && *trace != "table" && *trace != "csv" {
+		fmt.Fprintf(os.Stderr, "unknown trace format %q (want table or csv)\n", *trace)
+		os.Exit(2)
+	}
+
+	positions, err := parseTargets(*targets)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	dial, err := day1.NewDial(*size, *start, positions...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *inverse != "" {
+		if err := writeInverse(dial, *inverse, *minimize); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(dial.Targets) == 1 && *trace == "" && *workers == 0 {
+		aoc.MainArgs(day1.Solver{Dial: dial}, flag.Args())
+		return
+	}
+
+	path := aoc.InputPath(1, flag.Args())
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if *trace != "" {
+		if err := writeTrace(os.Stdout, *trace, dial, file); err != nil {
+			aoc.Fatal(path, err)
+		}
+		return
+	}
+
+	hits, err := solve(dial, file, *workers)
+	if err != nil {
+		aoc.Fatal(path, err)
+	}
+	fmt.Printf("Part 1: %d\n", hits.TotalEnd())
+	fmt.Printf("Part 2: %d\n", hits.TotalPass())
+	if len(dial.Targets) > 1 {
+		// With several targets, break the totals down per target.
+		for i, target := range dial.Targets {
+			fmt.Printf("  target %d: %d end, %d pass\n", target, hits.End[i], hits.Pass[i])
+		}
+	}
+}
+
+// solve reads the whole file, in parallel chunks when workers is positive.
+func solve(dial day1.Dial, file *os.File, workers int) (day1.Hits, error) {
+	if workers <= 0 {
+		return dial.Solve(file)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return day1.Hits{}, err
+	}
+	return dial.SolveParallel(file, info.Size(), workers)
+}
+
+// writeInverse prints a rotation list for the end,pass hit counts in spec.
+func writeInverse(dial day1.Dial, spec, minimize string) error {
+	counts, err := parseInts(spec)
+	if err != nil || len(counts) != 2 {
+		return fmt.Errorf("-inverse wants end,pass hit counts, got %q", spec)
+	}
+	var obj day1.Objective
+	switch minimize {
+	case "rotations":
+		obj = day1.FewestRotations
+	case "steps":
+		obj = day1.FewestSteps
+	default:
+		return fmt.Errorf("-minimize wants rotations or steps, got %q", minimize)
+	}
+	seq, err := dial.Inverse(counts[0], counts[1], obj)
+	if err != nil {
+		return err
+	}
+	_, err = seq.WriteTo(os.Stdout)
+	return err
+}
+
+func parseTargets(list string) ([]int, error) {
+	positions, err := parseInts(list)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target %w", err)
+	}
+	return positions, nil
+}
+
+func parseInts(list string) ([]int, error) {
+	var values []int
+	for _, field := range strings.Split(list, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("%q", field)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
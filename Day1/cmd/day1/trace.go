@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"aoc25/Day1"
+)
+
+// traceColumns names the columns of a trace: the rotation itself, then an
+// end and a pass column per watched target.
+func traceColumns(dial day1.Dial) []string {
+	cols := []string{"line", "dir", "steps", "before", "after"}
+	for _, t := range dial.Targets {
+		cols = append(cols, fmt.Sprintf("end@%d", t), fmt.Sprintf("pass@%d", t))
+	}
+	return cols
+}
+
+func traceRecord(step day1.Step) []string {
+	rec := []string{
+		strconv.Itoa(step.Line),
+		string(step.Dir),
+		strconv.Itoa(step.Steps),
+		strconv.Itoa(step.Before),
+		strconv.Itoa(step.After),
+	}
+	for i := range step.Hits.End {
+		rec = append(rec, strconv.Itoa(step.Hits.End[i]), strconv.Itoa(step.Hits.Pass[i]))
+	}
+	return rec
+}
+
+// writeTrace streams the trace of r through dial to w as an aligned table
+// ending in a totals row, or as CSV.
+func writeTrace(w io.Writer, format string, dial day1.Dial, r io.Reader) error {
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(traceColumns(dial)); err != nil {
+			return err
+		}
+		var writeErr error
+		err := dial.Trace(r, func(step day1.Step) bool {
+			writeErr = cw.Write(traceRecord(step))
+			return writeErr == nil
+		})
+		if err == nil {
+			err = writeErr
+		}
+		cw.Flush()
+		if err != nil {
+			return err
+		}
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+		writeRow(tw, traceColumns(dial))
+		totals := make([]int, 2*len(dial.Targets))
+		err := dial.Trace(r, func(step day1.Step) bool {
+			writeRow(tw, traceRecord(step))
+			for i := range step.Hits.End {
+				totals[2*i] += step.Hits.End[i]
+				totals[2*i+1] += step.Hits.Pass[i]
+			}
+			return true
+		})
+		if err != nil {
+			// Keep the rows traced before the bad line, as CSV does.
+			tw.Flush()
+			return err
+		}
+		row := []string{"total", "", "", "", ""}
+		for _, v := range totals {
+			row = append(row, strconv.Itoa(v))
+		}
+		writeRow(tw, row)
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown trace format %q (want table or csv)", format)
+}
+
+func writeRow(w io.Writer, fields []string) {
+	for _, f := range fields {
+		fmt.Fprintf(w, "%s\t", f)
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,130 @@
+// Package day1 solves Advent of Code 2025 day 1: Secret Entrance.
+package day1
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"aoc25/aoc"
+)
+
+// The puzzle's dial: 100 positions, starting at 50.
+const (
+	dialSize      = 100
+	startPosition = 50
+)
+
+type rotation struct {
+	dir   byte
+	steps int
+	// line is the input line the rotation was read from.
+	line int
+}
+
+// Solve turns the puzzle's dial through the rotations in r and returns how
+// many rotations end on 0 and how many clicks land on 0 in total.
+func Solve(r io.Reader) (int, int, error) {
+	hits, err := DefaultDial().Solve(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	return hits.End[0], hits.Pass[0], nil
+}
+
+func parseRotations(r io.Reader) ([]rotation, error) {
+	var rotations []rotation
+	_, err := scanRotations(r, func(rot rotation) bool {
+		rotations = append(rotations, rot)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rotations, nil
+}
+
+// scanRotations parses r one line at a time and passes each rotation to fn
+// until fn returns false. It returns the number of lines read. Lines are
+// read with a bufio.Reader rather than a Scanner so that no line is too
+// long, however much padding it carries.
+func scanRotations(r io.Reader, fn func(rotation) bool) (int, error) {
+	reader := bufio.NewReader(r)
+	lineNumber := 0
+
+	for {
+		raw, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return lineNumber, readErr
+		}
+		if readErr == io.EOF && raw == "" {
+			break
+		}
+		lineNumber++
+		raw = strings.TrimSuffix(strings.TrimSuffix(raw, "\n"), "\r")
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		col := aoc.LeadingSpace(raw) + 1
+
+		if len(line) < 2 {
+			return lineNumber, aoc.ParseErrorf(lineNumber, col, raw, "rotation too short")
+		}
+
+		dir := line[0]
+		if dir != 'L' && dir != 'R' {
+			return lineNumber, aoc.ParseErrorf(lineNumber, col, raw, "invalid direction %q", dir)
+		}
+		steps, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return lineNumber, aoc.ParseErrorf(lineNumber, col+1, raw, "invalid distance: %w", err)
+		}
+
+		if !fn(rotation{dir: dir, steps: steps, line: lineNumber}) {
+			return lineNumber, nil
+		}
+	}
+
+	return lineNumber, nil
+}
+
+// mod reduces value to a position on a dial of the given size.
+func mod(value, size int) int {
+	value %= size
+	if value < 0 {
+		value += size
+	}
+	return value
+}
+
+// countZeroHits counts the clicks of a rotation from position that land on
+// 0 on a dial of the given size.
+func countZeroHits(position, steps int, dir byte, size int) int {
+	if steps <= 0 {
+		return 0
+	}
+
+	var first int
+	switch dir {
+	case 'L':
+		first = position % size
+		if first == 0 {
+			first = size
+		}
+	case 'R':
+		first = (size - (position % size)) % size
+		if first == 0 {
+			first = size
+		}
+	default:
+		return 0
+	}
+
+	if steps < first {
+		return 0
+	}
+
+	return 1 + (steps-first)/size
+}
@@ -0,0 +1,390 @@
+package day1
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"aoc25/aoc"
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "3", Part2: "6"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "direction", Input: "L5\n\nX3\n", Line: 3, Column: 1},
+		{Name: "distance", Input: "R10\n  Lx\n", Line: 2, Column: 4},
+		{Name: "short", Input: "R\n", Line: 1, Column: 1},
+	})
+}
+
+func TestLongLines(t *testing.T) {
+	// Past bufio.Scanner's default 64KB token limit.
+	pad := strings.Repeat(" ", 100_000)
+	input := "L68\r\n" + pad + "L30" + pad + "\nR48"
+	end, pass, err := Solve(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if end != 1 || pass != 2 {
+		t.Fatalf("Solve() = %d, %d, want 1, 2", end, pass)
+	}
+}
+
+func TestSolveMultiRevolution(t *testing.T) {
+	const input = "R1000\n"
+
+	part1, part2, err := Solve(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+
+	const wantPart1 = 0
+	const wantPart2 = 10
+	if part1 != wantPart1 {
+		t.Fatalf("Solve() part1 = %d, want %d", part1, wantPart1)
+	}
+	if part2 != wantPart2 {
+		t.Fatalf("Solve() part2 = %d, want %d", part2, wantPart2)
+	}
+}
+
+// bruteForceHits turns the dial one click at a time.
+func bruteForceHits(d Dial, rotations []rotation) Hits {
+	hits := Hits{End: make([]int, len(d.Targets)), Pass: make([]int, len(d.Targets))}
+	position := d.Start
+	for _, rot := range rotations {
+		delta := 1
+		if rot.dir == 'L' {
+			delta = -1
+		}
+		for k := 0; k < rot.steps; k++ {
+			position = mod(position+delta, d.Size)
+			for i, t := range d.Targets {
+				if position == t {
+					hits.Pass[i]++
+				}
+			}
+		}
+		for i, t := range d.Targets {
+			if position == t {
+				hits.End[i]++
+			}
+		}
+	}
+	return hits
+}
+
+func TestDialMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		size := 1 + rng.Intn(30)
+		targets := rng.Perm(size)[:1+rng.Intn(min(size, 4))]
+		d, err := NewDial(size, rng.Intn(size), targets...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rotations := make([]rotation, 1+rng.Intn(20))
+		for i := range rotations {
+			rotations[i] = rotation{dir: "LR"[rng.Intn(2)], steps: rng.Intn(3 * size)}
+		}
+		got, want := d.run(rotations), bruteForceHits(d, rotations)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("dial %+v rotations %v: hits = %+v, want %+v", d, rotations, got, want)
+		}
+	}
+}
+
+func TestNewDialRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		size, start int
+		targets     []int
+	}{
+		{0, 0, []int{0}},
+		{10, 10, []int{0}},
+		{10, 0, nil},
+		{10, 0, []int{-1}},
+		{10, 0, []int{3, 3}},
+	}
+	for _, tt := range tests {
+		if _, err := NewDial(tt.size, tt.start, tt.targets...); err == nil {
+			t.Errorf("NewDial(%d, %d, %v) succeeded, want an error", tt.size, tt.start, tt.targets)
+		}
+	}
+}
+
+func TestTraceMatchesSolve(t *testing.T) {
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantEnd, wantPass, err := Solve(bytes.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := DefaultDial()
+	var steps []Step
+	err = d.Trace(bytes.NewReader(sample), func(s Step) bool {
+		steps = append(steps, s)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Trace() error = %v", err)
+	}
+
+	end, pass := 0, 0
+	position := d.Start
+	for i, s := range steps {
+		if s.Line != i+1 || s.Before != position {
+			t.Fatalf("step %d = %+v, want line %d starting at %d", i, s, i+1, position)
+		}
+		position = s.After
+		end += s.Hits.TotalEnd()
+		pass += s.Hits.TotalPass()
+	}
+	if end != wantEnd || pass != wantPass {
+		t.Fatalf("trace totals = %d, %d, want %d, %d", end, pass, wantEnd, wantPass)
+	}
+}
+
+func TestTraceLinesAndStop(t *testing.T) {
+	var lines []int
+	err := DefaultDial().Trace(strings.NewReader("L1\n\n  R2\nL3\nL4\n"), func(s Step) bool {
+		lines = append(lines, s.Line)
+		return len(lines) < 3
+	})
+	if err != nil {
+		t.Fatalf("Trace() error = %v", err)
+	}
+	if !reflect.DeepEqual(lines, []int{1, 3, 4}) {
+		t.Fatalf("traced lines = %v, want [1 3 4]", lines)
+	}
+}
+
+// minSteps finds the fewest clicks that give exactly end and pass hits on
+// d's target by searching over click-level states: position, direction of
+// the rotation in progress (0 between rotations) and hits so far.
+func minSteps(d Dial, end, pass int) int {
+	type state struct {
+		pos  int
+		dir  byte
+		end  int
+		pass int
+	}
+	target := d.Targets[0]
+	dist := map[state]int{}
+	start := state{pos: d.Start}
+	dist[start] = 0
+	// Costs are 0 or 1, so a deque gives Dijkstra order.
+	queue := []state{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		cost := dist[cur]
+		if cur.dir == 0 && cur.end == end && cur.pass == pass {
+			return cost
+		}
+		relax := func(next state, c int) {
+			if next.end > end || next.pass > pass {
+				return
+			}
+			if old, ok := dist[next]; ok && old <= cost+c {
+				return
+			}
+			dist[next] = cost + c
+			if c == 0 {
+				queue = append([]state{next}, queue...)
+			} else {
+				queue = append(queue, next)
+			}
+		}
+		click := func(dir byte) {
+			next := state{pos: d.turn(cur.pos, rotation{dir: dir, steps: 1}), dir: dir, end: cur.end, pass: cur.pass}
+			if next.pos == target {
+				next.pass++
+			}
+			relax(next, 1)
+		}
+		if cur.dir == 0 {
+			if cur.pos == target {
+				// A zero-step rotation: an end hit without a pass.
+				relax(state{pos: cur.pos, end: cur.end + 1, pass: cur.pass}, 0)
+			}
+			click('L')
+			click('R')
+			continue
+		}
+		click(cur.dir)
+		stop := state{pos: cur.pos, end: cur.end, pass: cur.pass}
+		if cur.pos == target {
+			stop.end++
+		}
+		relax(stop, 0)
+	}
+	return -1
+}
+
+func TestInverse(t *testing.T) {
+	for size := 1; size <= 6; size++ {
+		for start := 0; start < size; start++ {
+			d, err := NewDial(size, start, size/2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for pass := 0; pass <= 5; pass++ {
+				for end := 0; end <= 5; end++ {
+					if size == 1 && end == 0 && pass > 0 || pass == 0 && end > 0 && start != d.Targets[0] {
+						continue
+					}
+					checkInverse(t, d, end, pass)
+				}
+			}
+		}
+	}
+}
+
+func checkInverse(t *testing.T, d Dial, end, pass int) {
+	t.Helper()
+	byRotations, err := d.Inverse(end, pass, FewestRotations)
+	if err != nil {
+		t.Fatalf("dial %+v Inverse(%d, %d, FewestRotations) error = %v", d, end, pass, err)
+	}
+	wantLen := end
+	if end == 0 && pass > 0 {
+		wantLen = 1
+	}
+	if len(byRotations) != wantLen {
+		t.Errorf("dial %+v Inverse(%d, %d, FewestRotations) = %v, want %d rotations", d, end, pass, byRotations, wantLen)
+	}
+
+	bySteps, err := d.Inverse(end, pass, FewestSteps)
+	if err != nil {
+		t.Fatalf("dial %+v Inverse(%d, %d, FewestSteps) error = %v", d, end, pass, err)
+	}
+	if want := minSteps(d, end, pass); bySteps.TotalSteps() != want {
+		t.Errorf("dial %+v Inverse(%d, %d, FewestSteps) = %v (%d steps), want %d steps", d, end, pass, bySteps, bySteps.TotalSteps(), want)
+	}
+
+	// The written sequence must round-trip through the puzzle parser.
+	var buf bytes.Buffer
+	if _, err := bySteps.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	hits, err := d.Solve(&buf)
+	if err != nil || hits.End[0] != end || hits.Pass[0] != pass {
+		t.Errorf("dial %+v Solve(Inverse(%d, %d)) = %+v, %v", d, end, pass, hits, err)
+	}
+}
+
+func TestInverseStops(t *testing.T) {
+	onTarget, _ := NewDial(100, 0, 0)
+	for _, tt := range []struct {
+		dial       Dial
+		end, pass  int
+		rotations  string
+		fewestStep string
+	}{
+		{onTarget, 3, 0, "[R0 R0 R0]", "[R0 R0 R0]"},
+		{DefaultDial(), 3, 1, "[R50 R0 R0]", "[R50 R0 R0]"},
+	} {
+		for obj, want := range []string{tt.rotations, tt.fewestStep} {
+			seq, err := tt.dial.Inverse(tt.end, tt.pass, Objective(obj))
+			if err != nil || fmt.Sprint(seq) != want {
+				t.Errorf("Inverse(%d, %d, %d) = %v, %v, want %s", tt.end, tt.pass, obj, seq, err, want)
+			}
+		}
+	}
+}
+
+func TestInverseRejectsImpossible(t *testing.T) {
+	d := DefaultDial()
+	if _, err := d.Inverse(3, 0, FewestSteps); err == nil {
+		t.Errorf("Inverse(3, 0) away from the target succeeded, want an error")
+	}
+	one, _ := NewDial(1, 0, 0)
+	if _, err := one.Inverse(0, 1, FewestRotations); err == nil {
+		t.Errorf("Inverse(0, 1) on a one-position dial succeeded, want an error")
+	}
+	two, _ := NewDial(10, 0, 0, 5)
+	if _, err := two.Inverse(1, 1, FewestSteps); err == nil {
+		t.Errorf("Inverse with two targets succeeded, want an error")
+	}
+}
+
+func TestSolveChunksMatchesSolve(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 100; trial++ {
+		size := 1 + rng.Intn(120)
+		targets := rng.Perm(size)[:1+rng.Intn(min(size, 4))]
+		d, err := NewDial(size, rng.Intn(size), targets...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sb strings.Builder
+		for i := rng.Intn(60); i > 0; i-- {
+			if rng.Intn(8) == 0 {
+				sb.WriteString("\n")
+				continue
+			}
+			fmt.Fprintf(&sb, "%c%d\n", "LR"[rng.Intn(2)], rng.Intn(4*size))
+		}
+		input := sb.String()
+		if rng.Intn(2) == 0 {
+			input = strings.TrimSuffix(input, "\n")
+		}
+
+		want, err := d.Solve(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, chunks := range []int{1, 3, 17} {
+			got, err := d.solveChunks(strings.NewReader(input), int64(len(input)), chunks, 1+rng.Intn(4))
+			if err != nil {
+				t.Fatalf("solveChunks(%d chunks) error = %v", chunks, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("dial %+v input %q, %d chunks: hits = %+v, want %+v", d, input, chunks, got, want)
+			}
+		}
+	}
+}
+
+func TestSolveChunksParseErrorLine(t *testing.T) {
+	input := strings.Repeat("L12\n\n", 20) + "R7\nX3\nL1\n"
+	for _, chunks := range []int{1, 4, 30} {
+		_, err := DefaultDial().solveChunks(strings.NewReader(input), int64(len(input)), chunks, 3)
+		var pe *aoc.ParseError
+		if !errors.As(err, &pe) || pe.Line != 42 || pe.Column != 1 {
+			t.Errorf("solveChunks(%d chunks) error = %v, want a parse error at 42:1", chunks, err)
+		}
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
+
+func BenchmarkSolveParallel(b *testing.B) {
+	input := bytes.Repeat(synthetic(1000), 4)
+	d := DefaultDial()
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		if _, err := d.SolveParallel(bytes.NewReader(input), int64(len(input)), 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
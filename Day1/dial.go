@@ -0,0 +1,138 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Dial is a circular dial numbered 0 to Size-1 that starts at Start. Every
+// click that lands on one of the Targets counts as a hit for that target.
+type Dial struct {
+	Size    int
+	Start   int
+	Targets []int
+}
+
+// DefaultDial returns the puzzle's dial: 100 positions, starting at 50,
+// watching 0.
+func DefaultDial() Dial {
+	return Dial{Size: dialSize, Start: startPosition, Targets: []int{0}}
+}
+
+// NewDial returns a validated dial.
+func NewDial(size, start int, targets ...int) (Dial, error) {
+	d := Dial{Size: size, Start: start, Targets: targets}
+	if err := d.Validate(); err != nil {
+		return Dial{}, err
+	}
+	return d, nil
+}
+
+// Validate checks that the start and every target are positions on the dial
+// and that no target is watched twice.
+func (d Dial) Validate() error {
+	if d.Size <= 0 {
+		return fmt.Errorf("dial size %d must be positive", d.Size)
+	}
+	if d.Start < 0 || d.Start >= d.Size {
+		return fmt.Errorf("start %d outside dial of size %d", d.Start, d.Size)
+	}
+	if len(d.Targets) == 0 {
+		return errors.New("no target positions")
+	}
+	seen := make(map[int]bool, len(d.Targets))
+	for _, t := range d.Targets {
+		if t < 0 || t >= d.Size {
+			return fmt.Errorf("target %d outside dial of size %d", t, d.Size)
+		}
+		if seen[t] {
+			return fmt.Errorf("target %d listed twice", t)
+		}
+		seen[t] = true
+	}
+	return nil
+}
+
+// Hits holds the hit counts per watched target, indexed like Dial.Targets.
+type Hits struct {
+	// End counts the rotations that finish on the target.
+	End []int
+	// Pass counts every click that lands on the target, including the last
+	// click of a rotation.
+	Pass []int
+}
+
+// TotalEnd sums End over all targets.
+func (h Hits) TotalEnd() int { return sum(h.End) }
+
+// TotalPass sums Pass over all targets.
+func (h Hits) TotalPass() int { return sum(h.Pass) }
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Solve turns the dial through the rotations in r as it parses them, so the
+// rotations are never held in memory.
+func (d Dial) Solve(r io.Reader) (Hits, error) {
+	if err := d.Validate(); err != nil {
+		return Hits{}, err
+	}
+	hits := d.newHits()
+	position := d.Start
+	_, err := scanRotations(r, func(rot rotation) bool {
+		position = d.apply(position, rot, hits)
+		return true
+	})
+	if err != nil {
+		return Hits{}, err
+	}
+	return hits, nil
+}
+
+// run turns the dial through every rotation, counting hits per target.
+func (d Dial) run(rotations []rotation) Hits {
+	hits := d.newHits()
+	position := d.Start
+	for _, rot := range rotations {
+		position = d.apply(position, rot, hits)
+	}
+	return hits
+}
+
+func (d Dial) newHits() Hits {
+	return Hits{End: make([]int, len(d.Targets)), Pass: make([]int, len(d.Targets))}
+}
+
+// apply turns the dial by rot from position, adds the hits per target to
+// hits and returns the new position.
+func (d Dial) apply(position int, rot rotation, hits Hits) int {
+	next := d.turn(position, rot)
+	for i, target := range d.Targets {
+		hits.Pass[i] += d.hitsOn(target, position, rot)
+		if next == target {
+			hits.End[i]++
+		}
+	}
+	return next
+}
+
+// turn returns the position after rot, starting from position.
+func (d Dial) turn(position int, rot rotation) int {
+	if rot.dir == 'L' {
+		return mod(position-rot.steps, d.Size)
+	}
+	return mod(position+rot.steps, d.Size)
+}
+
+// hitsOn counts the clicks of rot, starting from position, that land on
+// target. Renumbering the dial so target sits at 0 reduces this to
+// countZeroHits, which is O(1) however long the rotation is.
+func (d Dial) hitsOn(target, position int, rot rotation) int {
+	return countZeroHits(mod(position-target, d.Size), rot.steps, rot.dir, d.Size)
+}
@@ -0,0 +1,222 @@
+package day1
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Rotation is one instruction of the puzzle input, such as L68.
+type Rotation struct {
+	Dir   byte
+	Steps int
+}
+
+func (r Rotation) String() string {
+	return fmt.Sprintf("%c%d", r.Dir, r.Steps)
+}
+
+// Sequence is a list of rotations that can be written out as puzzle input.
+type Sequence []Rotation
+
+// TotalSteps sums the steps of every rotation.
+func (s Sequence) TotalSteps() int {
+	total := 0
+	for _, r := range s {
+		total += r.Steps
+	}
+	return total
+}
+
+// WriteTo writes the sequence one rotation per line, the format Solve reads.
+func (s Sequence) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	for _, r := range s {
+		written, err := fmt.Fprintln(bw, r)
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, bw.Flush()
+}
+
+func (s Sequence) rotations() []rotation {
+	rotations := make([]rotation, len(s))
+	for i, r := range s {
+		rotations[i] = rotation{dir: r.Dir, steps: r.Steps, line: i + 1}
+	}
+	return rotations
+}
+
+// Objective selects what Inverse minimises.
+type Objective int
+
+const (
+	// FewestRotations minimises the number of rotations.
+	FewestRotations Objective = iota
+	// FewestSteps minimises the total number of clicks.
+	FewestSteps
+)
+
+// Inverse builds a sequence of rotations that, run on d, ends exactly
+// endHits rotations on the target and lands exactly passHits clicks on it.
+// d must watch a single target. A rotation that ends on the target after
+// moving also passes it, so end hits beyond the pass hits come from
+// zero-step rotations (R0) made while the dial rests on the target.
+func (d Dial) Inverse(endHits, passHits int, obj Objective) (Sequence, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	if len(d.Targets) != 1 {
+		return nil, fmt.Errorf("inverse needs exactly one target, got %d", len(d.Targets))
+	}
+	switch {
+	case endHits < 0 || passHits < 0:
+		return nil, errors.New("hit counts must not be negative")
+	case passHits == 0 && endHits > 0 && d.Start != d.Targets[0]:
+		return nil, errors.New("the dial cannot end on the target without passing it first")
+	case d.Size == 1 && endHits == 0 && passHits > 0:
+		// On a one-position dial every rotation ends on the target.
+		return nil, errors.New("a dial of size 1 cannot pass the target without ending on it")
+	}
+
+	var seq Sequence
+	switch obj {
+	case FewestRotations:
+		seq = d.fewestRotations(endHits, passHits)
+	case FewestSteps:
+		// Every landing costs the same whether or not it ends a rotation,
+		// so extra end hits are R0s after a sequence that ends on each
+		// pass.
+		seq = d.fewestSteps(min(endHits, passHits), passHits)
+		seq = append(seq, stops(endHits-passHits)...)
+	default:
+		return nil, fmt.Errorf("unknown objective %d", obj)
+	}
+
+	// Check the construction with the same arithmetic Solve uses.
+	hits := d.run(seq.rotations())
+	if hits.End[0] != endHits || hits.Pass[0] != passHits {
+		return nil, fmt.Errorf("internal error: sequence gives %d end and %d pass hits, want %d and %d",
+			hits.End[0], hits.Pass[0], endHits, passHits)
+	}
+	return seq, nil
+}
+
+// nearest returns the direction and number of clicks that first land on the
+// target from position, preferring R on a tie. From the target itself that
+// is a full turn.
+func (d Dial) nearest(position int) (byte, int) {
+	target := d.Targets[0]
+	right := mod(target-position, d.Size)
+	left := mod(position-target, d.Size)
+	if right == 0 {
+		return 'R', d.Size
+	}
+	if left < right {
+		return 'L', left
+	}
+	return 'R', right
+}
+
+// stops returns n zero-step rotations, each an end hit without a pass when
+// the dial rests on the target. It returns nil for n <= 0.
+func stops(n int) Sequence {
+	var seq Sequence
+	for i := 0; i < n; i++ {
+		seq = append(seq, Rotation{'R', 0})
+	}
+	return seq
+}
+
+// fewestRotations needs one rotation per end hit: the first lands on the
+// target as often as the surplus passes require, every later one is a full
+// turn. With no end hits a single rotation overshoots the last pass by one
+// click. With more end hits than passes, the first rotation makes every
+// pass and the rest are zero-step stops on the target.
+func (d Dial) fewestRotations(endHits, passHits int) Sequence {
+	if passHits == 0 {
+		return stops(endHits)
+	}
+	dir, first := d.nearest(d.Start)
+	if endHits > passHits {
+		return append(Sequence{{dir, first + (passHits-1)*d.Size}}, stops(endHits-1)...)
+	}
+	if endHits == 0 {
+		return Sequence{{dir, first + (passHits-1)*d.Size + 1}}
+	}
+	seq := Sequence{{dir, first + (passHits-endHits)*d.Size}}
+	for i := 1; i < endHits; i++ {
+		seq = append(seq, Rotation{dir, d.Size})
+	}
+	return seq
+}
+
+// fewestSteps walks to the target by the shorter way, then bounces one click
+// off it and back for every further pass, which is the cheapest way to land
+// on it again. The last endHits landings finish their rotation; the others
+// run one click past, and that click starts the next bounce. Adjacent
+// rotations in the same direction are merged when the boundary between them
+// is not an end hit.
+func (d Dial) fewestSteps(endHits, passHits int) Sequence {
+	if passHits == 0 {
+		return nil
+	}
+	if d.Size == 1 {
+		// Every click lands on the only position.
+		return d.fewestRotations(endHits, passHits)
+	}
+
+	var seq Sequence
+	dir, first := d.nearest(d.Start)
+	if d.Start == d.Targets[0] {
+		seq = append(seq, Rotation{'R', 1})
+		dir, first = 'L', 1
+	}
+	seq = append(seq, Rotation{dir, first})
+
+	for landing := 1; ; landing++ {
+		isEnd := landing > passHits-endHits
+		if !isEnd {
+			// Run one click past the target.
+			seq[len(seq)-1].Steps++
+		}
+		if landing == passHits {
+			break
+		}
+		if isEnd {
+			seq = append(seq, Rotation{'R', 1})
+			dir = 'L'
+		} else {
+			dir = opposite(dir)
+		}
+		seq = append(seq, Rotation{dir, 1})
+	}
+	return d.merge(seq)
+}
+
+// merge joins consecutive rotations in the same direction unless the first
+// one ends on the target.
+func (d Dial) merge(seq Sequence) Sequence {
+	var out Sequence
+	position := d.Start
+	for _, r := range seq {
+		if n := len(out); n > 0 && out[n-1].Dir == r.Dir && position != d.Targets[0] {
+			out[n-1].Steps += r.Steps
+		} else {
+			out = append(out, r)
+		}
+		position = d.turn(position, rotation{dir: r.Dir, steps: r.Steps})
+	}
+	return out
+}
+
+func opposite(dir byte) byte {
+	if dir == 'L' {
+		return 'R'
+	}
+	return 'L'
+}
@@ -0,0 +1,224 @@
+package day1
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+
+	"aoc25/aoc"
+)
+
+const (
+	// minChunkBytes keeps chunks large enough that summarising one costs far
+	// more than combining it.
+	minChunkBytes = 1 << 20
+	// chunksPerWorker splits the input finer than the worker count so a
+	// slow chunk does not leave the other workers idle.
+	chunksPerWorker = 4
+	// maxSummarySize bounds the dial size SolveParallel summarises; a
+	// summary holds two counters per position.
+	maxSummarySize = 1 << 20
+)
+
+// summary describes a run of rotations independently of where the dial
+// starts. Started at 0, the run turns the dial by offset, ends end[p]
+// rotations on position p and lands pass[p] clicks on it. Starting at s
+// instead shifts every position by s, so the hits on target t are
+// end[t-s] and pass[t-s]. That makes summaries of consecutive chunks
+// compose associatively.
+type summary struct {
+	offset int
+	end    []int
+	pass   []int
+	// lines is the number of input lines the run was read from.
+	lines int
+}
+
+// summarise reads rotations from r, starting the dial at 0.
+func (d Dial) summarise(r io.Reader) (summary, error) {
+	n := d.Size
+	s := summary{end: make([]int, n), pass: make([]int, n)}
+	// diff is a difference array for the partial laps: adding one to the
+	// positions lo..hi is diff[lo]++ and diff[hi+1]--.
+	diff := make([]int, n+1)
+	laps := 0
+	addRange := func(lo, hi int) {
+		if lo <= hi {
+			diff[lo]++
+			diff[hi+1]--
+			return
+		}
+		// The range wraps past the top of the dial.
+		diff[lo]++
+		diff[n]--
+		diff[0]++
+		diff[hi+1]--
+	}
+
+	position := 0
+	lines, err := scanRotations(r, func(rot rotation) bool {
+		if rot.steps > 0 {
+			laps += rot.steps / n
+			if rem := rot.steps % n; rem > 0 {
+				if rot.dir == 'R' {
+					addRange(mod(position+1, n), mod(position+rem, n))
+				} else {
+					addRange(mod(position-rem, n), mod(position-1, n))
+				}
+			}
+		}
+		position = d.turn(position, rot)
+		s.end[position]++
+		return true
+	})
+	if err != nil {
+		return summary{}, err
+	}
+
+	running := 0
+	for p := 0; p < n; p++ {
+		running += diff[p]
+		s.pass[p] = laps + running
+	}
+	s.offset = position
+	s.lines = lines
+	return s, nil
+}
+
+// then returns the summary of a followed by b.
+func (a summary) then(b summary, n int) summary {
+	out := summary{
+		offset: mod(a.offset+b.offset, n),
+		end:    make([]int, n),
+		pass:   make([]int, n),
+		lines:  a.lines + b.lines,
+	}
+	for p := 0; p < n; p++ {
+		// b starts where a left the dial, so its positions are shifted by
+		// a.offset.
+		q := mod(p-a.offset, n)
+		out.end[p] = a.end[p] + b.end[q]
+		out.pass[p] = a.pass[p] + b.pass[q]
+	}
+	return out
+}
+
+// hits reads the counts for d's start and targets off a summary.
+func (d Dial) hits(s summary) Hits {
+	hits := d.newHits()
+	for i, t := range d.Targets {
+		rel := mod(t-d.Start, d.Size)
+		hits.End[i] = s.end[rel]
+		hits.Pass[i] = s.pass[rel]
+	}
+	return hits
+}
+
+// SolveParallel gives the same answer as Solve for the size bytes of
+// rotations in r, but splits them into chunks at line boundaries and
+// summarises the chunks on up to workers goroutines (GOMAXPROCS when
+// workers is zero or less). Dials with more than a million positions are
+// solved sequentially, since each summary stores counts for every position.
+func (d Dial) SolveParallel(r io.ReaderAt, size int64, workers int) (Hits, error) {
+	if err := d.Validate(); err != nil {
+		return Hits{}, err
+	}
+	if d.Size > maxSummarySize {
+		return d.Solve(io.NewSectionReader(r, 0, size))
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunks := int(min(size/minChunkBytes, int64(workers*chunksPerWorker)))
+	return d.solveChunks(r, size, max(chunks, 1), workers)
+}
+
+func (d Dial) solveChunks(r io.ReaderAt, size int64, chunks, workers int) (Hits, error) {
+	bounds, err := chunkBounds(r, size, chunks)
+	if err != nil {
+		return Hits{}, err
+	}
+	chunks = len(bounds) - 1
+
+	summaries := make([]summary, chunks)
+	errs := make([]error, chunks)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, chunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				section := io.NewSectionReader(r, bounds[i], bounds[i+1]-bounds[i])
+				summaries[i], errs[i] = d.summarise(section)
+			}
+		}()
+	}
+	for i := 0; i < chunks; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	total := summaries[0]
+	if errs[0] != nil {
+		return Hits{}, errs[0]
+	}
+	for i := 1; i < chunks; i++ {
+		if errs[i] != nil {
+			// Line numbers are relative to the chunk; every chunk before it
+			// parsed cleanly, so total.lines is the line count before it.
+			var pe *aoc.ParseError
+			if errors.As(errs[i], &pe) {
+				pe.Line += total.lines
+			}
+			return Hits{}, errs[i]
+		}
+		total = total.then(summaries[i], d.Size)
+	}
+	return d.hits(total), nil
+}
+
+// chunkBounds splits size bytes of r into at most chunks ranges, moving
+// every boundary forward to the start of a line. Empty ranges are dropped.
+func chunkBounds(r io.ReaderAt, size int64, chunks int) ([]int64, error) {
+	bounds := []int64{0}
+	for i := 1; i < chunks; i++ {
+		at, err := nextLineStart(r, size, size*int64(i)/int64(chunks))
+		if err != nil {
+			return nil, err
+		}
+		if at > bounds[len(bounds)-1] && at < size {
+			bounds = append(bounds, at)
+		}
+	}
+	return append(bounds, size), nil
+}
+
+// nextLineStart returns the offset of the first line that starts at or
+// after off.
+func nextLineStart(r io.ReaderAt, size, off int64) (int64, error) {
+	if off <= 0 {
+		return 0, nil
+	}
+	// The line starts at off if the byte before it ends a line.
+	br := bufio.NewReader(io.NewSectionReader(r, off-1, size-off+1))
+	var buf [4096]byte
+	pos := off - 1
+	for {
+		n, err := br.Read(buf[:])
+		if i := bytes.IndexByte(buf[:n], '\n'); i != -1 {
+			return pos + int64(i) + 1, nil
+		}
+		pos += int64(n)
+		if err == io.EOF {
+			return size, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package day1
+
+import (
+	"io"
+	"sync"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's parser and dial simulation to the aoc.Solver
+// interface.
+type Solver struct {
+	// Dial is the dial to simulate; the zero value means DefaultDial. With
+	// several targets the parts sum the hits over all of them.
+	Dial Dial
+}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 1 }
+
+// Parse implements aoc.Solver.
+func (s Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	dial := s.Dial
+	if dial.Size == 0 {
+		dial = DefaultDial()
+	}
+	if err := dial.Validate(); err != nil {
+		return nil, err
+	}
+	rotations, err := parseRotations(r)
+	if err != nil {
+		return nil, err
+	}
+	return &puzzle{dial: dial, rotations: rotations}, nil
+}
+
+type puzzle struct {
+	dial      Dial
+	rotations []rotation
+
+	// hits is counted by whichever part runs first: one run of the dial
+	// gives both kinds of hit.
+	runOnce sync.Once
+	hits    Hits
+}
+
+func (p *puzzle) run() Hits {
+	p.runOnce.Do(func() { p.hits = p.dial.run(p.rotations) })
+	return p.hits
+}
+
+func (p *puzzle) Part1() (aoc.Result, error) {
+	return aoc.Int(int64(p.run().TotalEnd())), nil
+}
+
+func (p *puzzle) Part2() (aoc.Result, error) {
+	return aoc.Int(int64(p.run().TotalPass())), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,26 @@
+package day1
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// synthetic generates scale*1000 random rotations, with occasional long
+// multi-lap turns so countZeroHits sees every case.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	var buf bytes.Buffer
+	for i := 0; i < scale*1000; i++ {
+		dir := 'L'
+		if rng.Intn(2) == 0 {
+			dir = 'R'
+		}
+		steps := 1 + rng.Intn(99)
+		if rng.Intn(10) == 0 {
+			steps += rng.Intn(1000)
+		}
+		fmt.Fprintf(&buf, "%c%d\n", dir, steps)
+	}
+	return buf.Bytes()
+}
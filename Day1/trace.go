@@ -0,0 +1,32 @@
+package day1
+
+import "io"
+
+// Step is one rotation as the dial executed it.
+type Step struct {
+	// Line is the input line of the rotation.
+	Line   int
+	Dir    byte
+	Steps  int
+	Before int
+	After  int
+	// Hits holds this rotation's hits alone, per target.
+	Hits Hits
+}
+
+// Trace reads rotations from r and calls yield with each one as the dial
+// executes it, stopping early if yield returns false. Rotations are
+// streamed, so the whole input never has to fit in memory.
+func (d Dial) Trace(r io.Reader, yield func(Step) bool) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	position := d.Start
+	_, err := scanRotations(r, func(rot rotation) bool {
+		step := Step{Line: rot.line, Dir: rot.dir, Steps: rot.steps, Before: position, Hits: d.newHits()}
+		position = d.apply(position, rot, step.Hits)
+		step.After = position
+		return yield(step)
+	})
+	return err
+}
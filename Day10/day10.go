@@ -1,16 +1,19 @@
-package main
+// Package day10 solves Advent of Code 2025 day 10: Factory.
+package day10
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"math"
 	"math/big"
 	"math/bits"
-	"os"
 	"strconv"
 	"strings"
+
+	"aoc25/aoc"
 )
 
 type machine struct {
@@ -19,56 +22,65 @@ type machine struct {
 	jolts   []int
 }
 
-func main() {
-	path := resolveInputPath(os.Args)
+func Solve(r io.Reader) (int64, int64, error) {
+	return SolveContext(context.Background(), r)
+}
 
-	file, err := os.Open(path)
+// SolveContext is Solve with cancellation. Once ctx is done the press
+// searches stop and the error is an *aoc.CanceledError recording how many
+// machines were solved and the presses summed over them.
+func SolveContext(ctx context.Context, r io.Reader) (int64, int64, error) {
+	machines, err := parseMachines(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
-		os.Exit(1)
+		return 0, 0, err
 	}
-	defer file.Close()
-
-	part1, part2, err := Solve(file)
+	sumPart1, err := sumIndicatorPresses(ctx, machines)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "solve error: %v\n", err)
-		os.Exit(1)
+		return 0, 0, err
 	}
-
-	fmt.Printf("Part 1: %d\n", part1)
-	fmt.Printf("Part 2: %d\n", part2)
+	sumPart2, err := sumJoltagePresses(ctx, machines)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sumPart1, sumPart2, nil
 }
 
-func resolveInputPath(args []string) string {
-	if len(args) > 1 {
-		return args[1]
-	}
-	if _, err := os.Stat("Day10/input.txt"); err == nil {
-		return "Day10/input.txt"
+func sumIndicatorPresses(ctx context.Context, machines []machine) (int64, error) {
+	var total int64
+	for idx, m := range machines {
+		if err := ctx.Err(); err != nil {
+			return 0, machinesCanceled(idx, len(machines), total, err)
+		}
+		presses, err := minIndicatorPresses(m)
+		if err != nil {
+			return 0, fmt.Errorf("machine %d indicators: %w", idx+1, err)
+		}
+		total += int64(presses)
 	}
-	return "input.txt"
+	return total, nil
 }
 
-func Solve(r io.Reader) (int64, int64, error) {
-	machines, err := parseMachines(r)
-	if err != nil {
-		return 0, 0, err
-	}
-	var sumPart1 int64
-	var sumPart2 int64
+func sumJoltagePresses(ctx context.Context, machines []machine) (int64, error) {
+	in := aoc.NewInterrupt(ctx)
+	var total int64
 	for idx, m := range machines {
-		p1, err := minIndicatorPresses(m)
-		if err != nil {
-			return 0, 0, fmt.Errorf("machine %d indicators: %w", idx+1, err)
+		if err := ctx.Err(); err != nil {
+			return 0, machinesCanceled(idx, len(machines), total, err)
+		}
+		presses, err := minJoltagePresses(in, m)
+		if err := in.Err(); err != nil {
+			return 0, machinesCanceled(idx, len(machines), total, err)
 		}
-		sumPart1 += int64(p1)
-		p2, err := minJoltagePresses(m)
 		if err != nil {
-			return 0, 0, fmt.Errorf("machine %d jolts: %w", idx+1, err)
+			return 0, fmt.Errorf("machine %d jolts: %w", idx+1, err)
 		}
-		sumPart2 += p2
+		total += presses
 	}
-	return sumPart1, sumPart2, nil
+	return total, nil
+}
+
+func machinesCanceled(done, total int, presses int64, err error) error {
+	return &aoc.CanceledError{Done: done, Total: total, Unit: "machines", Partial: aoc.Int(presses), Err: err}
 }
 
 func parseMachines(r io.Reader) ([]machine, error) {
@@ -76,14 +88,17 @@ func parseMachines(r io.Reader) ([]machine, error) {
 	buf := make([]byte, 0, 1024)
 	scanner.Buffer(buf, 1<<20)
 	var machines []machine
+	lineNumber := 0
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+		lineNumber++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
 		if line == "" {
 			continue
 		}
 		m, err := parseMachineLine(line)
 		if err != nil {
-			return nil, err
+			return nil, aoc.AtLine(err, lineNumber, aoc.LeadingSpace(raw), raw)
 		}
 		machines = append(machines, m)
 	}
@@ -93,74 +108,79 @@ func parseMachines(r io.Reader) ([]machine, error) {
 	return machines, nil
 }
 
+// parseMachineLine parses one machine. Errors carry the column within line
+// and are placed on their input line by parseMachines.
 func parseMachineLine(line string) (machine, error) {
 	var result machine
 	open := strings.Index(line, "[")
 	closeIdx := strings.Index(line, "]")
 	if open == -1 || closeIdx == -1 || closeIdx <= open {
-		return result, fmt.Errorf("invalid indicator diagram: %q", line)
+		return result, columnError(1, "invalid indicator diagram: %q", line)
 	}
 	pattern := line[open+1 : closeIdx]
 	lights := make([]bool, len(pattern))
-	for i, ch := range pattern {
-		switch ch {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
 		case '.':
 			lights[i] = false
 		case '#':
 			lights[i] = true
 		default:
-			return result, fmt.Errorf("invalid indicator char %q", string(ch))
+			return result, columnError(open+2+i, "invalid indicator char %q", string(pattern[i]))
 		}
 	}
-	rest := strings.TrimSpace(line[closeIdx+1:])
+	pos := skipSpace(line, closeIdx+1)
 	var buttons [][]int
-	for len(rest) > 0 && rest[0] == '(' {
-		end := strings.Index(rest, ")")
+	var buttonCols []int
+	for pos < len(line) && line[pos] == '(' {
+		end := strings.IndexByte(line[pos:], ')')
 		if end == -1 {
-			return result, errors.New("missing closing parenthesis")
+			return result, columnError(pos+1, "missing closing parenthesis")
 		}
-		inside := rest[1:end]
-		idxs, err := parseIndexList(inside)
+		end += pos
+		idxs, err := parseIntList(line[pos+1:end], pos+2)
 		if err != nil {
 			return result, err
 		}
 		buttons = append(buttons, idxs)
-		rest = strings.TrimSpace(rest[end+1:])
+		buttonCols = append(buttonCols, pos+1)
+		pos = skipSpace(line, end+1)
 	}
-	if len(rest) == 0 || rest[0] != '{' {
-		return result, errors.New("missing joltage requirements")
+	if pos >= len(line) || line[pos] != '{' {
+		return result, columnError(pos+1, "missing joltage requirements")
 	}
-	end := strings.Index(rest, "}")
+	end := strings.IndexByte(line[pos:], '}')
 	if end == -1 {
-		return result, errors.New("missing closing brace")
+		return result, columnError(pos+1, "missing closing brace")
 	}
-	targetList := rest[1:end]
-	jolts, err := parseIntList(targetList)
+	end += pos
+	jolts, err := parseIntList(line[pos+1:end], pos+2)
 	if err != nil {
 		return result, err
 	}
-	rest = strings.TrimSpace(rest[end+1:])
-	if rest != "" {
-		return result, fmt.Errorf("unexpected trailing data %q", rest)
+	joltCol := pos + 1
+	pos = skipSpace(line, end+1)
+	if pos < len(line) {
+		return result, columnError(pos+1, "unexpected trailing data %q", line[pos:])
 	}
 	if len(jolts) == 0 && len(lights) != 0 {
-		return result, errors.New("joltage requirements missing entries")
+		return result, columnError(joltCol, "joltage requirements missing entries")
 	}
 	if len(lights) != len(jolts) && len(jolts) != 0 {
 		if len(lights) == 0 {
 			lights = make([]bool, len(jolts))
 		} else {
-			return result, fmt.Errorf("indicator lights (%d) and joltage counters (%d) mismatch", len(lights), len(jolts))
+			return result, columnError(joltCol, "indicator lights (%d) and joltage counters (%d) mismatch", len(lights), len(jolts))
 		}
 	}
 	// Validate indices
-	for _, btn := range buttons {
+	for b, btn := range buttons {
 		for _, idx := range btn {
 			if idx < 0 || idx >= len(lights) {
-				return result, fmt.Errorf("button references invalid index %d for %d lights", idx, len(lights))
+				return result, columnError(buttonCols[b], "button references invalid index %d for %d lights", idx, len(lights))
 			}
 			if idx >= len(jolts) {
-				return result, fmt.Errorf("button index %d beyond joltage counters %d", idx, len(jolts))
+				return result, columnError(buttonCols[b], "button index %d beyond joltage counters %d", idx, len(jolts))
 			}
 		}
 	}
@@ -168,42 +188,32 @@ func parseMachineLine(line string) (machine, error) {
 	return result, nil
 }
 
-func parseIndexList(s string) ([]int, error) {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return nil, nil
-	}
-	parts := strings.Split(s, ",")
-	values := make([]int, 0, len(parts))
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
-		val, err := strconv.Atoi(part)
-		if err != nil {
-			return nil, err
-		}
-		values = append(values, val)
+// columnError reports a problem at col of the line being parsed.
+func columnError(col int, format string, args ...any) error {
+	return aoc.ParseErrorf(0, col, "", format, args...)
+}
+
+func skipSpace(line string, pos int) int {
+	for pos < len(line) && (line[pos] == ' ' || line[pos] == '\t') {
+		pos++
 	}
-	return values, nil
+	return pos
 }
 
-func parseIntList(s string) ([]int, error) {
-	s = strings.TrimSpace(s)
-	if s == "" {
+// parseIntList parses a comma-separated list that starts at column col.
+func parseIntList(s string, col int) ([]int, error) {
+	if strings.TrimSpace(s) == "" {
 		return nil, nil
 	}
-	parts := strings.Split(s, ",")
+	parts, cols := aoc.SplitColumns(s, ",")
 	values := make([]int, 0, len(parts))
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
+	for i, part := range parts {
 		if part == "" {
 			continue
 		}
 		val, err := strconv.Atoi(part)
 		if err != nil {
-			return nil, err
+			return nil, columnError(col+cols[i]-1, "invalid number %q: %w", part, err)
 		}
 		values = append(values, val)
 	}
@@ -338,7 +348,10 @@ func minWeightInAffine(part []uint64, basis [][]uint64) int {
 	return best
 }
 
-func minJoltagePresses(m machine) (int64, error) {
+// minJoltagePresses finds the fewest presses that reach every joltage
+// target. If in stops the search the result is meaningless; callers check
+// in.Err.
+func minJoltagePresses(in *aoc.Interrupt, m machine) (int64, error) {
 	rows := len(m.jolts)
 	cols := len(m.buttons)
 	if rows == 0 {
@@ -437,7 +450,7 @@ func minJoltagePresses(m machine) (int64, error) {
 	}
 	var dfs func(int, int64)
 	dfs = func(idx int, sum int64) {
-		if sum >= best {
+		if sum >= best || in.Stopped() {
 			return
 		}
 		if idx == len(freeCols) {
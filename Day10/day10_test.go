@@ -0,0 +1,53 @@
+package day10
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"testing"
+
+	"aoc25/aoc"
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "7", Part2: "33"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "diagram", Input: "(0) {1}\n", Line: 1, Column: 1},
+		{Name: "indicator", Input: "[.#] (0) {1,1}\n[.x] (0) {1,1}\n", Line: 2, Column: 3},
+		{Name: "button", Input: "  [.#] (0) (1,a) {1,1}\n", Line: 1, Column: 15},
+		{Name: "index", Input: "[.#] (0) (2) {1,1}\n", Line: 1, Column: 10},
+		{Name: "trailing", Input: "[.#] (0) {1,1} x\n", Line: 1, Column: 16},
+	})
+}
+
+func TestSolveContextCanceled(t *testing.T) {
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = SolveContext(ctx, bytes.NewReader(sample))
+	var ce *aoc.CanceledError
+	if !errors.As(err, &ce) || !errors.Is(err, aoc.ErrCanceled) {
+		t.Fatalf("SolveContext() error = %v, want *aoc.CanceledError", err)
+	}
+	if ce.Done != 0 || ce.Total != 3 || ce.Unit != "machines" {
+		t.Fatalf("progress = %d/%d %s, want 0/3 machines", ce.Done, ce.Total, ce.Unit)
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
@@ -0,0 +1,57 @@
+package day10
+
+import (
+	"context"
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's machine parser and press minimisation to the
+// aoc.Solver interface.
+type Solver struct{}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 10 }
+
+// Parse implements aoc.Solver.
+func (Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	machines, err := parseMachines(r)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{machines: machines}, nil
+}
+
+type puzzle struct {
+	machines []machine
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	return p.Part1Context(context.Background())
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	return p.Part2Context(context.Background())
+}
+
+// Part1Context implements aoc.ContextPuzzle.
+func (p puzzle) Part1Context(ctx context.Context) (aoc.Result, error) {
+	total, err := sumIndicatorPresses(ctx, p.machines)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(total), nil
+}
+
+// Part2Context implements aoc.ContextPuzzle.
+func (p puzzle) Part2Context(ctx context.Context) (aoc.Result, error) {
+	total, err := sumJoltagePresses(ctx, p.machines)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(total), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,65 @@
+package day10
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// synthetic generates scale*2 machines with four to six counters. Targets are
+// built from random press counts so every machine has a solution.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	var buf bytes.Buffer
+	for i := 0; i < scale*2; i++ {
+		n := 4 + rng.Intn(3)
+		buttons := make([][]int, n+2)
+		for b := range buttons {
+			for idx := 0; idx < n; idx++ {
+				if rng.Intn(2) == 0 {
+					buttons[b] = append(buttons[b], idx)
+				}
+			}
+			if len(buttons[b]) == 0 {
+				buttons[b] = []int{rng.Intn(n)}
+			}
+		}
+		lights := make([]bool, n)
+		jolts := make([]int, n)
+		for _, btn := range buttons {
+			toggle := rng.Intn(2) == 0
+			presses := rng.Intn(6)
+			for _, idx := range btn {
+				if toggle {
+					lights[idx] = !lights[idx]
+				}
+				jolts[idx] += presses
+			}
+		}
+
+		buf.WriteByte('[')
+		for _, on := range lights {
+			if on {
+				buf.WriteByte('#')
+			} else {
+				buf.WriteByte('.')
+			}
+		}
+		buf.WriteByte(']')
+		for _, btn := range buttons {
+			fmt.Fprintf(&buf, " (%s)", joinInts(btn))
+		}
+		fmt.Fprintf(&buf, " {%s}\n", joinInts(jolts))
+	}
+	return buf.Bytes()
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
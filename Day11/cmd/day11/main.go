@@ -0,0 +1,10 @@
+package main
+
+import (
+	"aoc25/Day11"
+	"aoc25/aoc"
+)
+
+func main() {
+	aoc.Main(day11.Solver{})
+}
@@ -1,90 +1,66 @@
-package main
+// Package day11 solves Advent of Code 2025 day 11: Reactor.
+package day11
 
 import (
 	"bufio"
-	"fmt"
 	"io"
-	"os"
 	"strings"
-)
-
-func main() {
-	path := resolveInputPath(os.Args)
 
-	file, err := os.Open(path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
-		os.Exit(1)
-	}
-	defer file.Close()
-
-	part1, part2, err := Solve(file)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "solve error: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Part 1: %d\n", part1)
-	fmt.Printf("Part 2: %d\n", part2)
-}
-
-func resolveInputPath(args []string) string {
-	if len(args) > 1 {
-		return args[1]
-	}
-	if _, err := os.Stat("Day11/input.txt"); err == nil {
-		return "Day11/input.txt"
-	}
-	return "input.txt"
-}
+	"aoc25/aoc"
+)
 
 // Solve reads a directed graph specification and returns:
 // - number of distinct simple paths from "you" to "out"
 // - number of distinct simple paths from "svr" to "out" that visit both "dac" and "fft"
 func Solve(r io.Reader) (int64, int64, error) {
-	graph, err := parseGraph(r)
+	graph, err := parseGraph(r, false)
 	if err != nil {
 		return 0, 0, err
 	}
+	return pathsFromYou(graph), pathsFromServer(graph), nil
+}
 
-	// Part 1: paths from "you" to "out"
-	var p1 int64
-	if _, ok := graph["you"]; ok {
-		// Try optimized DAG DP; if cycle detected, fall back to DFS
-		pruned, order, ok := prunedTopo(graph, "you", "out")
-		if ok {
-			p1 = countPathsDAG(pruned, order, "you", "out")
-		} else {
-			p1 = countPathsSimple(graph, "you", "out")
-		}
-	} else {
-		p1 = 0
+// pathsFromYou counts the paths from "you" to "out" (part 1).
+func pathsFromYou(graph map[string][]string) int64 {
+	if _, ok := graph["you"]; !ok {
+		return 0
 	}
-
-	// Part 2: paths from "svr" to "out" that visit both dac and fft
-	var p2 int64
-	if _, ok := graph["svr"]; ok {
-		// Try optimized DAG DP with 2-bit mask for dac/fft; fallback to DFS on cycles
-		pruned, order, ok := prunedTopo(graph, "svr", "out")
-		if ok {
-			p2 = countPathsMustVisitDAG(pruned, order, "svr", "out", "dac", "fft")
-		} else {
-			p2 = countPathsWithMustVisit(graph, "svr", "out", "dac", "fft")
-		}
-	} else {
-		p2 = 0
+	// Try optimized DAG DP; if cycle detected, fall back to DFS
+	pruned, order, ok := prunedTopo(graph, "you", "out")
+	if ok {
+		return countPathsDAG(pruned, order, "you", "out")
 	}
+	return countPathsSimple(graph, "you", "out")
+}
 
-	return p1, p2, nil
+// pathsFromServer counts the paths from "svr" to "out" that visit both "dac"
+// and "fft" (part 2).
+func pathsFromServer(graph map[string][]string) int64 {
+	if _, ok := graph["svr"]; !ok {
+		return 0
+	}
+	// Try optimized DAG DP with 2-bit mask for dac/fft; fallback to DFS on cycles
+	pruned, order, ok := prunedTopo(graph, "svr", "out")
+	if ok {
+		return countPathsMustVisitDAG(pruned, order, "svr", "out", "dac", "fft")
+	}
+	return countPathsWithMustVisit(graph, "svr", "out", "dac", "fft")
 }
 
-func parseGraph(r io.Reader) (map[string][]string, error) {
+// parseGraph reads "name: target ..." lines. By default lines without a
+// colon are skipped and repeated definitions of a node are merged; strict
+// rejects both.
+func parseGraph(r io.Reader, strict bool) (map[string][]string, error) {
 	scanner := bufio.NewScanner(r)
 	buf := make([]byte, 0, 1024)
 	scanner.Buffer(buf, 1<<20)
 	graph := make(map[string][]string)
+	definedAt := make(map[string]int)
+	lineNumber := 0
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+		lineNumber++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
 		if line == "" {
 			continue
 		}
@@ -92,13 +68,27 @@ func parseGraph(r io.Reader) (map[string][]string, error) {
 		if strings.HasPrefix(line, "#") {
 			continue
 		}
+		col := aoc.LeadingSpace(raw) + 1
 		// Expect format: name: a b c
 		parts := strings.SplitN(line, ":", 2)
 		if len(parts) != 2 {
+			if strict {
+				return nil, aoc.ParseErrorf(lineNumber, col, raw, "expected \"name: targets\"")
+			}
 			// If the line has no colon, skip it gracefully
 			continue
 		}
 		src := strings.TrimSpace(parts[0])
+		if src == "" {
+			return nil, aoc.ParseErrorf(lineNumber, col, raw, "missing node name")
+		}
+		if i := strings.IndexAny(src, " \t"); i != -1 {
+			return nil, aoc.ParseErrorf(lineNumber, col+i, raw, "node name %q contains whitespace", src)
+		}
+		if first, ok := definedAt[src]; ok && strict {
+			return nil, aoc.ParseErrorf(lineNumber, col, raw, "node %q already defined on line %d", src, first)
+		}
+		definedAt[src] = lineNumber
 		targets := strings.Fields(strings.TrimSpace(parts[1]))
 		// ensure node exists even with no targets
 		if _, exists := graph[src]; !exists {
@@ -0,0 +1,43 @@
+package day11
+
+import (
+	"embed"
+	"testing"
+
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "5", Part2: "0"},
+		{File: "sample2.txt", Part1: "0", Part2: "2"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "missing name", Input: "you: a\n  : b\n", Line: 2, Column: 3},
+		{Name: "space in name", Input: "# comment\nyo u: a\n", Line: 2, Column: 3},
+	})
+}
+
+func TestStrictParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{Strict: true}, []aoctest.BadInput{
+		{Name: "no colon", Input: "you: a\nyou a\n", Line: 2, Column: 1},
+		{Name: "duplicate", Input: "you: a\na: out\n # later\n you: b\n", Line: 4, Column: 2},
+	})
+}
+
+func TestStrictAcceptsSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{Strict: true}, []aoctest.Case{
+		{File: "sample.txt", Part1: "5", Part2: "0"},
+		{File: "sample2.txt", Part1: "0", Part2: "2"},
+	})
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
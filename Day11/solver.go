@@ -0,0 +1,48 @@
+package day11
+
+import (
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's graph parser and path counting to the aoc.Solver
+// interface.
+type Solver struct {
+	// Strict rejects lines without a colon and nodes defined more than once
+	// instead of skipping or merging them.
+	Strict bool
+}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 11 }
+
+// Parse implements aoc.Solver.
+func (s Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	graph, err := parseGraph(r, s.Strict)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{graph: graph}, nil
+}
+
+type puzzle struct {
+	graph map[string][]string
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	return aoc.Int(pathsFromYou(p.graph)), nil
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	return aoc.Int(pathsFromServer(p.graph)), nil
+}
+
+// WithStrict implements aoc.StrictParser.
+func (s Solver) WithStrict() aoc.Solver {
+	s.Strict = true
+	return s
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,49 @@
+package day11
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// synthetic generates a layered DAG ten layers deep with scale*10 devices per
+// layer. Both "you" and "svr" feed the first layer, "dac" and "fft" sit in
+// the middle layers, and the last layer feeds "out".
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	const layers = 10
+	width := scale * 10
+	name := func(layer, idx int) string {
+		switch {
+		case layer == 3 && idx == 0:
+			return "dac"
+		case layer == 6 && idx == 0:
+			return "fft"
+		}
+		return fmt.Sprintf("n%d_%d", layer, idx)
+	}
+
+	var buf bytes.Buffer
+	for _, src := range []string{"you", "svr"} {
+		fmt.Fprintf(&buf, "%s:", src)
+		for idx := 0; idx < width; idx += 1 + rng.Intn(3) {
+			fmt.Fprintf(&buf, " %s", name(0, idx))
+		}
+		buf.WriteByte('\n')
+	}
+	for layer := 0; layer < layers; layer++ {
+		for idx := 0; idx < width; idx++ {
+			fmt.Fprintf(&buf, "%s:", name(layer, idx))
+			if layer == layers-1 {
+				buf.WriteString(" out\n")
+				continue
+			}
+			fmt.Fprintf(&buf, " %s %s", name(layer+1, rng.Intn(width)), name(layer+1, 0))
+			if rng.Intn(2) == 0 {
+				fmt.Fprintf(&buf, " %s", name(layer+1, rng.Intn(width)))
+			}
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
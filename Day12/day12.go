@@ -1,14 +1,16 @@
-package main
+// Package day12 solves Advent of Code 2025 day 12: Christmas Tree Farm.
+package day12
 
 import (
 	"bufio"
-	"fmt"
+	"context"
 	"io"
-	"os"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+
+	"aoc25/aoc"
 )
 
 type shape struct {
@@ -19,50 +21,47 @@ type shape struct {
 
 type pt struct{ x, y int }
 
-func main() {
-	path := resolveInputPath(os.Args)
-
-	f, err := os.Open(path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
-		os.Exit(1)
-	}
-	defer f.Close()
-
-	p1, p2, err := Solve(f)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "solve error: %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Part 1: %d\n", p1)
-	fmt.Printf("Part 2: %d\n", p2)
-}
-
-func resolveInputPath(args []string) string {
-	if len(args) > 1 {
-		return args[1]
-	}
-	if _, err := os.Stat("Day12/input.txt"); err == nil {
-		return "Day12/input.txt"
-	}
-	return "input.txt"
-}
-
 // Solve parses shapes and regions; returns how many regions can fit the requested presents (part1).
 // There is no Part 2 for this day; it returns 0.
 func Solve(r io.Reader) (int64, int64, error) {
-	shapes, regions, err := parseInput(r)
+	return SolveContext(context.Background(), r)
+}
+
+// SolveContext is Solve with cancellation. Once ctx is done the packing
+// search stops and the error is an *aoc.CanceledError recording how many
+// regions were evaluated and how many of those fit.
+func SolveContext(ctx context.Context, r io.Reader) (int64, int64, error) {
+	shapes, regions, err := parseInput(r, false)
 	if err != nil {
 		return 0, 0, err
 	}
+	count, err := countFittingRegions(ctx, shapes, regions)
+	if err != nil {
+		return 0, 0, err
+	}
+	return count, 0, nil
+}
 
+func countFittingRegions(ctx context.Context, shapes []shape, regions []regionSpec) (int64, error) {
+	in := aoc.NewInterrupt(ctx)
 	var count int64
-	for _, reg := range regions {
-		if canFitAll(shapes, reg.w, reg.h, reg.counts) {
+	for i, reg := range regions {
+		if err := ctx.Err(); err != nil {
+			return 0, regionsCanceled(i, len(regions), count, err)
+		}
+		fits := canFitAll(in, shapes, reg.w, reg.h, reg.counts)
+		if err := in.Err(); err != nil {
+			return 0, regionsCanceled(i, len(regions), count, err)
+		}
+		if fits {
 			count++
 		}
 	}
-	return count, 0, nil
+	return count, nil
+}
+
+func regionsCanceled(done, total int, fitting int64, err error) error {
+	return &aoc.CanceledError{Done: done, Total: total, Unit: "regions", Partial: aoc.Int(fitting), Err: err}
 }
 
 type regionSpec struct {
@@ -70,7 +69,11 @@ type regionSpec struct {
 	counts []int
 }
 
-func parseInput(r io.Reader) ([]shape, []regionSpec, error) {
+// parseInput reads the shape list and the regions. By default unexpected
+// lines are skipped and each region's counts are padded or trimmed to the
+// number of shapes; strict rejects those along with shape characters other
+// than '#' and '.'.
+func parseInput(r io.Reader, strict bool) ([]shape, []regionSpec, error) {
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 0, 1024), 1<<20)
 	var lines []string
@@ -94,12 +97,23 @@ func parseInput(r io.Reader) ([]shape, []regionSpec, error) {
 		if regionRe.MatchString(line) {
 			break
 		}
+		col := aoc.LeadingSpace(lines[i]) + 1
 		// Expect something like "0:" then grid lines of '#' '.' until blank line
 		if !strings.HasSuffix(line, ":") {
+			if strict {
+				return nil, nil, aoc.ParseErrorf(i+1, col, lines[i], "expected shape header \"N:\" or region \"WxH:\"")
+			}
 			// Skip unexpected lines
 			i++
 			continue
 		}
+		if strict {
+			index := strings.TrimSpace(strings.TrimSuffix(line, ":"))
+			if index != strconv.Itoa(len(rawShapes)) {
+				return nil, nil, aoc.ParseErrorf(i+1, col, lines[i], "shape %q out of order, want %d", index, len(rawShapes))
+			}
+		}
+		header := i
 		i++
 		var grid [][]rune
 		for i < len(lines) {
@@ -107,11 +121,23 @@ func parseInput(r io.Reader) ([]shape, []regionSpec, error) {
 			if strings.TrimSpace(l) == "" {
 				break
 			}
+			if strict {
+				offset := aoc.LeadingSpace(l)
+				trimmed := strings.TrimSpace(l)
+				for j := 0; j < len(trimmed); j++ {
+					if trimmed[j] != '#' && trimmed[j] != '.' {
+						return nil, nil, aoc.ParseErrorf(i+1, offset+j+1, l, "invalid shape cell %q", trimmed[j])
+					}
+				}
+			}
 			// grid line: consist of '#' and '.'
 			row := []rune(strings.TrimSpace(l))
 			grid = append(grid, row)
 			i++
 		}
+		if strict && len(grid) == 0 {
+			return nil, nil, aoc.ParseErrorf(header+1, col, lines[header], "shape has no rows")
+		}
 		rawShapes = append(rawShapes, grid)
 		// consume blank line if present
 		for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
@@ -136,24 +162,30 @@ func parseInput(r io.Reader) ([]shape, []regionSpec, error) {
 		if line == "" {
 			continue
 		}
+		raw := lines[i-1]
+		col := aoc.LeadingSpace(raw) + 1
 		m := regionRe.FindStringSubmatch(line)
 		if m == nil {
+			if strict {
+				return nil, nil, aoc.ParseErrorf(i, col, raw, "expected region \"WxH: counts\"")
+			}
 			continue
 		}
-		w, _ := strconv.Atoi(m[1])
-		h, _ := strconv.Atoi(m[2])
-		after := strings.TrimSpace(line[strings.Index(line, ":")+1:])
-		var counts []int
-		if after != "" {
-			parts := strings.Fields(after)
-			counts = make([]int, len(parts))
-			for j, p := range parts {
-				v, err := strconv.Atoi(p)
-				if err != nil {
-					v = 0
-				}
-				counts[j] = v
-			}
+		w, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, nil, aoc.ParseErrorf(i, col, raw, "invalid width: %w", err)
+		}
+		h, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, nil, aoc.ParseErrorf(i, col+len(m[1])+1, raw, "invalid height: %w", err)
+		}
+		counts, err := parseCounts(raw, strings.IndexByte(raw, ':')+1)
+		if err != nil {
+			return nil, nil, aoc.AtLine(err, i, 0, raw)
+		}
+		if strict && len(counts) != len(shapes) {
+			return nil, nil, aoc.ParseErrorf(i, strings.IndexByte(raw, ':')+1, raw,
+				"got %d counts for %d shapes", len(counts), len(shapes))
 		}
 		// Pad or trim counts to number of shapes
 		if len(counts) < len(shapes) {
@@ -169,6 +201,32 @@ func parseInput(r io.Reader) ([]shape, []regionSpec, error) {
 	return shapes, regions, nil
 }
 
+// parseCounts reads the whitespace-separated shape counts in line from
+// offset on. Errors carry their column in line.
+func parseCounts(line string, offset int) ([]int, error) {
+	var counts []int
+	for pos := offset; pos < len(line); {
+		if line[pos] == ' ' || line[pos] == '\t' {
+			pos++
+			continue
+		}
+		end := pos
+		for end < len(line) && line[end] != ' ' && line[end] != '\t' {
+			end++
+		}
+		v, err := strconv.Atoi(line[pos:end])
+		if err != nil {
+			return nil, aoc.ParseErrorf(0, pos+1, "", "invalid count %q", line[pos:end])
+		}
+		if v < 0 {
+			return nil, aoc.ParseErrorf(0, pos+1, "", "negative count %d", v)
+		}
+		counts = append(counts, v)
+		pos = end
+	}
+	return counts, nil
+}
+
 func extractCells(grid [][]rune) []pt {
 	var cells []pt
 	for y, row := range grid {
@@ -264,7 +322,10 @@ func genOrientations(base []pt) [][]pt {
 	return res
 }
 
-func canFitAll(shapes []shape, W, H int, counts []int) bool {
+// canFitAll reports whether the presents in counts can be packed into a W×H
+// region. If in stops the search the result is meaningless; callers check
+// in.Err.
+func canFitAll(in *aoc.Interrupt, shapes []shape, W, H int, counts []int) bool {
 	// Fast area feasibility checks
 	totalArea := 0
 	for i, c := range counts {
@@ -377,16 +438,19 @@ func canFitAll(shapes []shape, W, H int, counts []int) bool {
 		return true
 	}
 
-	if exactCoverExists(rows, nCols, nCellCols) {
+	if exactCoverExists(in, rows, nCols, nCellCols) {
 		return true
 	}
+	if in.Stopped() {
+		return false
+	}
 	// Fallback: use backtracking (safer correctness if DLX modeling missed a case)
-	return canFitAllBacktrack(shapes, W, H, counts)
+	return canFitAllBacktrack(in, shapes, W, H, counts)
 }
 
 // exactCoverExists implements Algorithm X with a lightweight DLX-like state.
 // It checks if there exists a subset of rows covering every column exactly once.
-func exactCoverExists(rows [][]int, nCols int, nCellCols int) bool {
+func exactCoverExists(in *aoc.Interrupt, rows [][]int, nCols int, nCellCols int) bool {
 	if nCols == 0 {
 		return true
 	}
@@ -493,6 +557,9 @@ func exactCoverExists(rows [][]int, nCols int, nCellCols int) bool {
 
 	var dfs func() bool
 	dfs = func() bool {
+		if in.Stopped() {
+			return false
+		}
 		if allCovered() {
 			return true
 		}
@@ -568,7 +635,7 @@ func exactCoverExists(rows [][]int, nCols int, nCellCols int) bool {
 }
 
 // Legacy backtracking placement used as a fallback to ensure correctness
-func canFitAllBacktrack(shapes []shape, W, H int, counts []int) bool {
+func canFitAllBacktrack(in *aoc.Interrupt, shapes []shape, W, H int, counts []int) bool {
 	type item struct{ idx, area int }
 	var items []item
 	for i, c := range counts {
@@ -593,6 +660,9 @@ func canFitAllBacktrack(shapes []shape, W, H int, counts []int) bool {
 		if pos == len(items) {
 			return true
 		}
+		if in.Stopped() {
+			return false
+		}
 		sIdx := items[pos].idx
 		s := shapes[sIdx]
 		for _, orient := range s.orients {
@@ -0,0 +1,115 @@
+package day12
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"testing"
+
+	"aoc25/aoc"
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "2"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "count", Input: "0:\n##\n\n4x4: 1 x\n", Line: 4, Column: 8},
+		{Name: "negative", Input: "0:\n##\n\n4x4: -1\n", Line: 4, Column: 6},
+	})
+}
+
+func TestStrictParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{Strict: true}, []aoctest.BadInput{
+		{Name: "unknown line", Input: "0:\n##\n\nshapes below\n4x4: 1\n", Line: 4, Column: 1},
+		{Name: "shape order", Input: "0:\n##\n\n2:\n#.\n\n4x4: 1 1\n", Line: 4, Column: 1},
+		{Name: "shape cell", Input: "0:\n##\n#o\n\n4x4: 1\n", Line: 3, Column: 2},
+		{Name: "empty shape", Input: "0:\n\n4x4: 1\n", Line: 1, Column: 1},
+		{Name: "region", Input: "0:\n##\n\n4x4: 1\n4 by 4: 1\n", Line: 5, Column: 1},
+		{Name: "too few counts", Input: "0:\n##\n\n1:\n#\n\n4x4: 1\n", Line: 7, Column: 4},
+		{Name: "too many counts", Input: "0:\n##\n\n4x4: 1 2\n", Line: 4, Column: 4},
+	})
+}
+
+func TestStrictAcceptsValidInput(t *testing.T) {
+	inputs := map[string][]byte{"synthetic": synthetic(1)}
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs["sample"] = sample
+	for name, input := range inputs {
+		if _, err := (Solver{Strict: true}).Parse(bytes.NewReader(input)); err != nil {
+			t.Errorf("strict Parse(%s) error = %v", name, err)
+		}
+	}
+}
+
+func TestSolveContextCanceled(t *testing.T) {
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = SolveContext(ctx, bytes.NewReader(sample))
+	var ce *aoc.CanceledError
+	if !errors.As(err, &ce) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("SolveContext() error = %v, want *aoc.CanceledError", err)
+	}
+	if ce.Done != 0 || ce.Total != 3 || ce.Unit != "regions" {
+		t.Fatalf("progress = %d/%d %s, want 0/3 regions", ce.Done, ce.Total, ce.Unit)
+	}
+}
+
+// cancelingContext reports itself canceled from the cancelAt-th call to
+// Err on, so a test can cancel a search part way through without a timer.
+type cancelingContext struct {
+	context.Context
+	done     chan struct{}
+	calls    int
+	cancelAt int
+}
+
+func (c *cancelingContext) Done() <-chan struct{} { return c.done }
+
+func (c *cancelingContext) Err() error {
+	c.calls++
+	if c.calls >= c.cancelAt {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestSolveContextStopsSearch(t *testing.T) {
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The region loop checks the context once per region, three times at
+	// most, so the tenth check comes from inside a search.
+	ctx := &cancelingContext{Context: context.Background(), done: make(chan struct{}), cancelAt: 10}
+
+	_, _, err = SolveContext(ctx, bytes.NewReader(sample))
+	var ce *aoc.CanceledError
+	if !errors.As(err, &ce) || !errors.Is(err, context.Canceled) || ce.Unit != "regions" {
+		t.Fatalf("SolveContext() error = %v, want canceled regions", err)
+	}
+	// The search must stop at the check that saw the cancellation.
+	if ctx.calls != ctx.cancelAt {
+		t.Fatalf("context checked %d times, want %d", ctx.calls, ctx.cancelAt)
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
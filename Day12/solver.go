@@ -0,0 +1,66 @@
+package day12
+
+import (
+	"context"
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's parser and packing search to the aoc.Solver
+// interface.
+type Solver struct {
+	// Strict rejects unknown lines, shape characters other than '#' and
+	// '.', and regions whose count list does not match the number of shapes
+	// instead of skipping or repairing them.
+	Strict bool
+}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 12 }
+
+// Parse implements aoc.Solver.
+func (s Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	shapes, regions, err := parseInput(r, s.Strict)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{shapes: shapes, regions: regions}, nil
+}
+
+type puzzle struct {
+	shapes  []shape
+	regions []regionSpec
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	return p.Part1Context(context.Background())
+}
+
+// Part2 returns the zero Result: day 12 has no second part.
+func (p puzzle) Part2() (aoc.Result, error) {
+	return aoc.Result{}, nil
+}
+
+// Part1Context implements aoc.ContextPuzzle.
+func (p puzzle) Part1Context(ctx context.Context) (aoc.Result, error) {
+	count, err := countFittingRegions(ctx, p.shapes, p.regions)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(count), nil
+}
+
+// Part2Context implements aoc.ContextPuzzle.
+func (p puzzle) Part2Context(context.Context) (aoc.Result, error) {
+	return p.Part2()
+}
+
+// WithStrict implements aoc.StrictParser.
+func (s Solver) WithStrict() aoc.Solver {
+	s.Strict = true
+	return s
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
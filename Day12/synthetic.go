@@ -0,0 +1,72 @@
+package day12
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// syntheticShapes are the six presents from the puzzle's example.
+const syntheticShapes = `0:
+###
+##.
+##.
+
+1:
+###
+##.
+.##
+
+2:
+.##
+###
+##.
+
+3:
+##.
+###
+##.
+
+4:
+###
+#..
+###
+
+5:
+###
+.#.
+###
+
+`
+
+// synthetic generates scale*10 regions mixing roomy regions, which the exact
+// cover search solves quickly, with regions that fail the area check.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	var buf bytes.Buffer
+	buf.WriteString(syntheticShapes)
+	for i := 0; i < scale*10; i++ {
+		counts := make([]int, 6)
+		var w, h int
+		switch rng.Intn(3) {
+		case 0:
+			w, h = 3, 3
+			counts[rng.Intn(6)] = 1
+		case 1:
+			w, h = 6, 3
+			counts[rng.Intn(6)]++
+			counts[rng.Intn(6)]++
+		default:
+			w, h = 5, 5
+			for k := 0; k < 4; k++ {
+				counts[rng.Intn(6)]++
+			}
+		}
+		fmt.Fprintf(&buf, "%dx%d:", w, h)
+		for _, c := range counts {
+			fmt.Fprintf(&buf, " %d", c)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
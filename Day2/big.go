@@ -0,0 +1,163 @@
+package day2
+
+import (
+	"math/big"
+	"strings"
+)
+
+// The math/big path mirrors the int64 one in day2.go for IDs of any length.
+
+type bigRange struct {
+	start *big.Int
+	end   *big.Int
+}
+
+// parseBigRanges is parseRanges for bounds that overflow an int64.
+func parseBigRanges(text string, base int) ([]bigRange, error) {
+	var ranges []bigRange
+	err := splitRanges(text, func(start, end string, offset, endOffset int) error {
+		lo, ok := parseBigID(start, base)
+		if !ok {
+			return rangeError(text, offset, "invalid start %q", start)
+		}
+		hi, ok := parseBigID(end, base)
+		if !ok {
+			return rangeError(text, endOffset, "invalid end %q", end)
+		}
+		if lo.Cmp(hi) > 0 {
+			return rangeError(text, offset, "range start %s greater than end %s", start, end)
+		}
+		ranges = append(ranges, bigRange{start: lo, end: hi})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+// parseBigID parses a non-empty run of digits in base. Like strconv, it
+// takes letters in either case.
+func parseBigID(s string, base int) (*big.Int, bool) {
+	if s == "" || strings.Trim(strings.ToLower(s), digitAlphabet[:base]) != "" {
+		return nil, false
+	}
+	return new(big.Int).SetString(s, base)
+}
+
+const digitAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+func widen(ranges []idRange) []bigRange {
+	wide := make([]bigRange, len(ranges))
+	for i, rg := range ranges {
+		wide[i] = bigRange{start: big.NewInt(rg.start), end: big.NewInt(rg.end)}
+	}
+	return wide
+}
+
+type bigTally struct {
+	count *big.Int
+	sum   *big.Int
+}
+
+func newBigTally() bigTally {
+	return bigTally{count: new(big.Int), sum: new(big.Int)}
+}
+
+func (t bigTally) add(o bigTally) {
+	t.count.Add(t.count, o.count)
+	t.sum.Add(t.sum, o.sum)
+}
+
+func (t bigTally) sub(o bigTally) {
+	t.count.Sub(t.count, o.count)
+	t.sum.Sub(t.sum, o.sum)
+}
+
+var bigOne = big.NewInt(1)
+
+func bigPow(base, n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(n)), nil)
+}
+
+// digits returns the number of digits of the positive n in base.
+func digits(n *big.Int, base int) int {
+	return len(n.Text(base))
+}
+
+func bigRepeatMultiplier(base, blockLen, repeats int) *big.Int {
+	m := bigPow(base, blockLen*repeats)
+	m.Sub(m, bigOne)
+	return m.Quo(m, new(big.Int).Sub(bigPow(base, blockLen), bigOne))
+}
+
+// bigMultiples is multiples with big integers.
+func bigMultiples(start, end, multiplier *big.Int, base, blockLen int) bigTally {
+	// loBase = max(base^(blockLen-1), ceil(start/multiplier))
+	loBase := new(big.Int).Add(start, multiplier)
+	loBase.Sub(loBase, bigOne)
+	loBase.Quo(loBase, multiplier)
+	if minBase := bigPow(base, blockLen-1); loBase.Cmp(minBase) < 0 {
+		loBase = minBase
+	}
+	// hiBase = min(base^blockLen - 1, end/multiplier)
+	hiBase := new(big.Int).Quo(end, multiplier)
+	if maxBase := new(big.Int).Sub(bigPow(base, blockLen), bigOne); hiBase.Cmp(maxBase) > 0 {
+		hiBase = maxBase
+	}
+
+	t := newBigTally()
+	if loBase.Cmp(hiBase) > 0 {
+		return t
+	}
+	t.count.Sub(hiBase, loBase)
+	t.count.Add(t.count, bigOne)
+	t.sum.Add(loBase, hiBase)
+	t.sum.Mul(t.sum, t.count)
+	t.sum.Rsh(t.sum, 1)
+	t.sum.Mul(t.sum, multiplier)
+	return t
+}
+
+func bigTallyRange(rg bigRange, base int, rule Rule) bigTally {
+	t := newBigTally()
+	for length := 2; length <= digits(rg.end, base); length++ {
+		segmentStart := bigPow(base, length-1)
+		if rg.start.Cmp(segmentStart) > 0 {
+			segmentStart = rg.start
+		}
+		segmentEnd := new(big.Int).Sub(bigPow(base, length), bigOne)
+		if rg.end.Cmp(segmentEnd) < 0 {
+			segmentEnd = rg.end
+		}
+		if segmentStart.Cmp(segmentEnd) > 0 {
+			continue
+		}
+		t.add(bigTallyLengthSegment(segmentStart, segmentEnd, base, length, rule))
+	}
+	return t
+}
+
+// bigTallyLengthSegment is tallyLengthSegment with big integers.
+func bigTallyLengthSegment(start, end *big.Int, base, length int, rule Rule) bigTally {
+	divisors := properDivisors(length)
+	tallies := make([]bigTally, len(divisors))
+	total := newBigTally()
+
+	for idx, d := range divisors {
+		g := bigMultiples(start, end, bigRepeatMultiplier(base, d, length/d), base, d)
+		for j := 0; j < idx; j++ {
+			smaller := divisors[j]
+			if d%smaller == 0 {
+				g.sub(tallies[j])
+			}
+		}
+
+		tallies[idx] = g
+		if rule.repeats(length/d) > 0 {
+			total.add(g)
+		}
+	}
+
+	return total
+}
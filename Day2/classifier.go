@@ -0,0 +1,36 @@
+package day2
+
+import (
+	"io"
+	"math/big"
+)
+
+// Classifier decides which IDs are invalid: those written in Base as some
+// block of digits repeated a number of times that Rule accepts. Digits past
+// 9 are the letters a-z, in either case. A zero Base means base 10.
+type Classifier struct {
+	Base int
+	Rule Rule
+}
+
+// Validate reports whether c has a usable base and rule.
+func (c Classifier) Validate() error {
+	if err := validBase(orDecimal(c.Base)); err != nil {
+		return err
+	}
+	return c.Rule.Validate()
+}
+
+// Tally parses the ranges in r and returns how many IDs in them are invalid
+// and what those IDs add up to.
+func (c Classifier) Tally(r io.Reader) (count, sum *big.Int, err error) {
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+	p, err := parseInput(r, orDecimal(c.Base))
+	if err != nil {
+		return nil, nil, err
+	}
+	countResult, sumResult := p.tally(c.Rule)
+	return countResult.Big(), sumResult.Big(), nil
+}
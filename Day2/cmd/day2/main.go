@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+
+	"aoc25/Day2"
+	"aoc25/aoc"
+)
+
+func main() {
+	base := flag.Int("base", 10, "numeral base the IDs are written in, 2-36")
+	explain := flag.String("explain", "", "instead of solving, list the IDs invalid under part 1, 2 or a `rule` (exactly:K, atleast:K, prime) with the block each repeats")
+	flag.Parse()
+	if *base < 2 || *base > 36 {
+		fmt.Fprintf(os.Stderr, "-base wants 2-36, got %d\n", *base)
+		os.Exit(2)
+	}
+	if *explain == "" {
+		aoc.MainArgs(day2.Solver{Base: *base}, flag.Args())
+		return
+	}
+	classifier, err := parseClassifier(*base, *explain)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	path := aoc.InputPath(2, flag.Args())
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	var count int64
+	sum := new(big.Int)
+	current := -1
+	err = classifier.Invalid(file, func(v day2.InvalidID) bool {
+		if v.Range != current {
+			current = v.Range
+			fmt.Fprintf(w, "%s-%s\n", strconv.FormatInt(v.Start, v.Base), strconv.FormatInt(v.End, v.Base))
+		}
+		fmt.Fprintf(w, "  %v\n", v)
+		count++
+		sum.Add(sum, big.NewInt(v.ID))
+		return true
+	})
+	if err != nil {
+		w.Flush()
+		aoc.Fatal(path, err)
+	}
+	fmt.Fprintf(w, "%d invalid IDs, sum %d\n", count, sum)
+}
+
+// parseClassifier reads the -explain argument: a part number or a rule.
+func parseClassifier(base int, spec string) (day2.Classifier, error) {
+	c := day2.Classifier{Base: base}
+	switch spec {
+	case "1":
+		c.Rule = day2.RepeatedTwice
+	case "2":
+		c.Rule = day2.AnyRepeat
+	default:
+		rule, err := day2.ParseRule(spec)
+		if err != nil {
+			return c, err
+		}
+		c.Rule = rule
+	}
+	return c, c.Validate()
+}
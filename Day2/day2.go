@@ -0,0 +1,420 @@
+// Package day2 solves Advent of Code 2025 day 2: Gift Shop.
+package day2
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+
+	"aoc25/aoc"
+)
+
+// Solve returns the sums for both parts. It fails when a sum does not fit
+// in an int64; Solver returns those as big integers instead.
+func Solve(r io.Reader) (int64, int64, error) {
+	p, err := parseInput(r, 10)
+	if err != nil {
+		return 0, 0, err
+	}
+	return bothInt64(p.sumInvalid(RepeatedTwice), p.sumInvalid(AnyRepeat))
+}
+
+// Count is Solve counting the invalid IDs instead of summing them.
+func Count(r io.Reader) (int64, int64, error) {
+	p, err := parseInput(r, 10)
+	if err != nil {
+		return 0, 0, err
+	}
+	return bothInt64(p.countInvalid(RepeatedTwice), p.countInvalid(AnyRepeat))
+}
+
+func bothInt64(part1, part2 aoc.Result) (int64, int64, error) {
+	a, ok := part1.Int64()
+	if !ok {
+		return 0, 0, fmt.Errorf("part 1 answer %s overflows int64", part1)
+	}
+	b, ok := part2.Int64()
+	if !ok {
+		return 0, 0, fmt.Errorf("part 2 answer %s overflows int64", part2)
+	}
+	return a, b, nil
+}
+
+// parseInput reads the ranges, written in base, as int64s, switching to big
+// integers when a bound overflows an int64 or an ID can have more digits
+// than the int64 path handles.
+func parseInput(r io.Reader, base int) (puzzle, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return puzzle{}, err
+	}
+
+	text := string(data)
+	if strings.TrimSpace(text) == "" {
+		return puzzle{}, &aoc.ParseError{Msg: "input is empty"}
+	}
+
+	num := newNumeral(base)
+	ranges, err := parseRanges(text, base)
+	if errors.Is(err, strconv.ErrRange) {
+		wide, err := parseBigRanges(text, base)
+		if err != nil {
+			return puzzle{}, err
+		}
+		return puzzle{num: num, wide: wide}, nil
+	}
+	if err != nil {
+		return puzzle{}, err
+	}
+	for _, rg := range ranges {
+		if rg.end >= num.pow[num.maxDigits()] {
+			return puzzle{num: num, wide: widen(ranges)}, nil
+		}
+	}
+	return puzzle{num: num, ranges: ranges}, nil
+}
+
+type puzzle struct {
+	num    numeral
+	ranges []idRange
+	// wide holds the ranges instead of ranges when they need big integers.
+	wide []bigRange
+}
+
+func (p puzzle) sumInvalid(rule Rule) aoc.Result {
+	_, sum := p.tally(rule)
+	return sum
+}
+
+func (p puzzle) countInvalid(rule Rule) aoc.Result {
+	count, _ := p.tally(rule)
+	return count
+}
+
+// tally counts and sums the IDs invalid under rule, as int64s when they
+// fit and as big integers otherwise.
+func (p puzzle) tally(rule Rule) (count, sum aoc.Result) {
+	wide := p.wide
+	if wide == nil {
+		var total tally
+		for _, rg := range p.ranges {
+			total.add(p.num.tallyRange(rg, rule))
+		}
+		if !total.overflow {
+			return aoc.Int(total.count), aoc.Int(total.sum)
+		}
+		wide = widen(p.ranges)
+	}
+
+	total := newBigTally()
+	for _, rg := range wide {
+		total.add(bigTallyRange(rg, p.num.base, rule))
+	}
+	return aoc.BigInt(total.count), aoc.BigInt(total.sum)
+}
+
+type idRange struct {
+	start int64
+	end   int64
+}
+
+// parseRanges reads the comma-separated ranges in text, with bounds
+// written in base, reporting errors at their line and column in text.
+func parseRanges(text string, base int) ([]idRange, error) {
+	var ranges []idRange
+	err := splitRanges(text, func(start, end string, offset, endOffset int) error {
+		lo, err := strconv.ParseInt(start, base, 64)
+		if err != nil {
+			return rangeError(text, offset, "invalid start %q: %w", start, err)
+		}
+		hi, err := strconv.ParseInt(end, base, 64)
+		if err != nil {
+			return rangeError(text, endOffset, "invalid end %q: %w", end, err)
+		}
+		if lo > hi {
+			return rangeError(text, offset, "range start %s greater than end %s", start, end)
+		}
+		ranges = append(ranges, idRange{start: lo, end: hi})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+// splitRanges calls fn with the text of each comma-separated start-end
+// range in text and the offsets of its start and end.
+func splitRanges(text string, fn func(start, end string, offset, endOffset int) error) error {
+	found := false
+	offset := 0
+	for _, part := range strings.Split(text, ",") {
+		partOffset := offset + aoc.LeadingSpace(part)
+		offset += len(part) + 1
+
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		dash := strings.IndexByte(part, '-')
+		if dash == -1 {
+			return rangeError(text, partOffset, "missing '-' in %q", part)
+		}
+		if err := fn(part[:dash], part[dash+1:], partOffset, partOffset+dash+1); err != nil {
+			return err
+		}
+		found = true
+	}
+
+	if !found {
+		return &aoc.ParseError{Msg: "no ranges found"}
+	}
+	return nil
+}
+
+// rangeError builds a ParseError for the byte at offset in text.
+func rangeError(text string, offset int, format string, args ...any) error {
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	lineEnd := strings.IndexByte(text[offset:], '\n')
+	if lineEnd == -1 {
+		lineEnd = len(text)
+	} else {
+		lineEnd += offset
+	}
+	line := strings.Count(text[:lineStart], "\n") + 1
+	snippet := strings.TrimRight(text[lineStart:lineEnd], "\r")
+	return aoc.ParseErrorf(line, offset-lineStart+1, snippet, format, args...)
+}
+
+// numeral holds the powers of a base that the int64 path works with:
+// pow[i] is base^i for every power that fits in an int64.
+type numeral struct {
+	base int
+	pow  []int64
+}
+
+func newNumeral(base int) numeral {
+	pow := []int64{1}
+	for {
+		next, ok := mulInt64(pow[len(pow)-1], int64(base))
+		if !ok {
+			break
+		}
+		pow = append(pow, next)
+	}
+	return numeral{base: base, pow: pow}
+}
+
+// decimal is the puzzle's own base.
+var decimal = newNumeral(10)
+
+// maxDigits is the longest ID the int64 path handles, 18 in base 10.
+// Longer IDs go through the math/big path in big.go.
+func (n numeral) maxDigits() int {
+	return len(n.pow) - 1
+}
+
+// orDecimal maps the zero base of Solver and Classifier to 10.
+func orDecimal(base int) int {
+	if base == 0 {
+		return 10
+	}
+	return base
+}
+
+// validBase reports whether IDs can be written in base, using 0-9 then a-z
+// as digits.
+func validBase(base int) error {
+	if base < 2 || base > 36 {
+		return fmt.Errorf("base %d out of range 2-36", base)
+	}
+	return nil
+}
+
+// tally is how many invalid IDs a range holds and what they add up to.
+type tally struct {
+	count int64
+	sum   int64
+	// overflow is set once a total no longer fits in an int64. count and
+	// sum are meaningless from then on and the tally has to be redone with
+	// big integers.
+	overflow bool
+}
+
+func (t *tally) add(o tally) {
+	var countOK, sumOK bool
+	t.count, countOK = addInt64(t.count, o.count)
+	t.sum, sumOK = addInt64(t.sum, o.sum)
+	t.overflow = t.overflow || o.overflow || !countOK || !sumOK
+}
+
+// sub removes a tally of a subset of t's IDs, which cannot overflow.
+func (t *tally) sub(o tally) {
+	t.count -= o.count
+	t.sum -= o.sum
+	t.overflow = t.overflow || o.overflow
+}
+
+// addInt64 adds two non-negative int64s and reports whether the sum fits.
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	return sum, sum >= 0
+}
+
+// mulInt64 multiplies two non-negative int64s and reports whether the
+// product fits.
+func mulInt64(a, b int64) (int64, bool) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int64(lo), hi == 0 && int64(lo) >= 0
+}
+
+// multiples tallies block*multiplier over the blockLen-digit blocks whose
+// products fall in [start, end].
+func (n numeral) multiples(start, end, multiplier int64, blockLen int) tally {
+	loBase, hiBase := n.blockBounds(start, end, multiplier, blockLen)
+	if loBase > hiBase {
+		return tally{}
+	}
+	count := hiBase - loBase + 1
+	// The bases add up to (loBase+hiBase)*count/2. One of the two factors
+	// is even, so halve it first to keep the product in range.
+	var sumBases int64
+	var ok bool
+	if count%2 == 0 {
+		sumBases, ok = mulInt64(loBase+hiBase, count/2)
+	} else {
+		sumBases, ok = mulInt64((loBase+hiBase)/2, count)
+	}
+	sum, sumOK := mulInt64(sumBases, multiplier)
+	return tally{count: count, sum: sum, overflow: !ok || !sumOK}
+}
+
+// blockBounds returns the smallest and largest blockLen-digit blocks whose
+// product with multiplier lies in [start, end]. lo > hi if there are none.
+func (n numeral) blockBounds(start, end, multiplier int64, blockLen int) (lo, hi int64) {
+	lo = maxInt64(n.pow[blockLen-1], ceilDiv(start, multiplier))
+	hi = minInt64(n.pow[blockLen]-1, end/multiplier)
+	return lo, hi
+}
+
+func ceilDiv(num, denom int64) int64 {
+	if denom <= 0 {
+		panic("denominator must be positive")
+	}
+	if num >= 0 {
+		return (num + denom - 1) / denom
+	}
+	return num / denom
+}
+
+func (n numeral) tallyRange(rg idRange, rule Rule) tally {
+	var t tally
+	for length := 2; length <= n.maxDigits() && n.pow[length-1] <= rg.end; length++ {
+		segmentStart := maxInt64(rg.start, n.pow[length-1])
+		segmentEnd := minInt64(rg.end, n.pow[length]-1)
+		if segmentStart > segmentEnd {
+			continue
+		}
+		t.add(n.tallyLengthSegment(segmentStart, segmentEnd, length, rule))
+	}
+	return t
+}
+
+// tallyLengthSegment tallies the length-digit IDs in [start, end] that rule
+// rejects. An ID whose shortest block has d digits is also a repeat of every
+// multiple of d that divides length, so the tally g(d) of IDs built from
+// d-digit blocks subtracts the tallies of each smaller divisor of d, leaving
+// the IDs whose shortest block is exactly d digits. Those are invalid when
+// rule accepts some divisor of length/d as a repeat count.
+func (n numeral) tallyLengthSegment(start, end int64, length int, rule Rule) tally {
+	divisors := properDivisors(length)
+	if len(divisors) == 0 {
+		return tally{}
+	}
+	// No length an int64 holds has more than 11 proper divisors.
+	var buf [12]tally
+	tallies := buf[:len(divisors)]
+	var total tally
+
+	for idx, d := range divisors {
+		g := n.multiples(start, end, n.repeatMultiplier(d, length/d), d)
+		for j := 0; j < idx; j++ {
+			smaller := divisors[j]
+			if d%smaller == 0 {
+				g.sub(tallies[j])
+			}
+		}
+
+		tallies[idx] = g
+		if rule.repeats(length/d) > 0 {
+			total.add(g)
+		}
+	}
+
+	return total
+}
+
+// divisorTable caches properDivisors for every length the int64 path sees.
+var divisorTable = func() [][]int {
+	table := make([][]int, 64)
+	for n := range table {
+		table[n] = findProperDivisors(n)
+	}
+	return table
+}()
+
+// properDivisors returns the divisors of n below n in increasing order. The
+// result must not be modified.
+func properDivisors(n int) []int {
+	if n < len(divisorTable) {
+		return divisorTable[n]
+	}
+	return findProperDivisors(n)
+}
+
+func findProperDivisors(n int) []int {
+	if n <= 1 {
+		return nil
+	}
+	var divs []int
+	for d := 1; d*d <= n; d++ {
+		if n%d != 0 {
+			continue
+		}
+		if d < n {
+			divs = append(divs, d)
+		}
+		other := n / d
+		if other != d && other < n {
+			divs = append(divs, other)
+		}
+	}
+	sort.Ints(divs)
+	return divs
+}
+
+// repeatMultiplier turns a blockLen-digit block into the ID that repeats it
+// repeats times.
+func (n numeral) repeatMultiplier(blockLen, repeats int) int64 {
+	totalDigits := blockLen * repeats
+	return (n.pow[totalDigits] - 1) / (n.pow[blockLen] - 1)
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,263 @@
+package day2
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"aoc25/aoc"
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "1227775554", Part2: "4174379265"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "empty", Input: "\n", Line: 0, Column: 0},
+		{Name: "missing dash", Input: "11-22, 95\n", Line: 1, Column: 8},
+		{Name: "end", Input: "11-22,95-1x5", Line: 1, Column: 10},
+		{Name: "reversed", Input: "11-22,\n30-20", Line: 2, Column: 1},
+	})
+}
+
+func TestCountSample(t *testing.T) {
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	twice, anyRepeat, err := Count(bytes.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if twice != 8 || anyRepeat != 13 {
+		t.Fatalf("Count() = %d, %d, want 8, 13", twice, anyRepeat)
+	}
+}
+
+// bruteForceBlock returns the shortest block that id, written in base,
+// repeats a number of times rule accepts, or "" if the ID is valid. Prime
+// only accepts the shortest block of all.
+func bruteForceBlock(id int64, base int, rule Rule) (string, int) {
+	s := strconv.FormatInt(id, base)
+	for d := 1; d < len(s); d++ {
+		if len(s)%d != 0 || strings.Repeat(s[:d], len(s)/d) != s {
+			continue
+		}
+		if rule.allows(len(s) / d) {
+			return s[:d], len(s) / d
+		}
+		if rule == Prime() {
+			break
+		}
+	}
+	return "", 0
+}
+
+var testRules = []Rule{RepeatedTwice, AnyRepeat, Exactly(3), AtLeast(3), Prime()}
+
+func TestInvalidMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	bases := []int{2, 3, 7, 10, 16, 36}
+	for trial := 0; trial < 300; trial++ {
+		base := bases[rng.Intn(len(bases))]
+		num := newNumeral(base)
+		digits := 1 + rng.Intn(min(7, num.maxDigits()))
+		start := rng.Int63n(num.pow[digits])
+		end := start + rng.Int63n(5000)
+		input := strconv.FormatInt(start, base) + "-" + strings.ToUpper(strconv.FormatInt(end, base))
+
+		for _, rule := range testRules {
+			var want []InvalidID
+			for id := start; id <= end; id++ {
+				if block, repeats := bruteForceBlock(id, base, rule); block != "" {
+					want = append(want, InvalidID{ID: id, Base: base, Block: block, Repeats: repeats, Start: start, End: end})
+				}
+			}
+			var got []InvalidID
+			c := Classifier{Base: base, Rule: rule}
+			if err := c.Invalid(strings.NewReader(input), func(v InvalidID) bool {
+				got = append(got, v)
+				return true
+			}); err != nil {
+				t.Fatalf("Invalid(%q) error = %v", input, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("%+v Invalid(%q) = %v, want %v", c, input, got, want)
+			}
+
+			var count, sum int64
+			for _, v := range want {
+				count++
+				sum += v.ID
+			}
+			rg := idRange{start: start, end: end}
+			if got := num.tallyRange(rg, rule); got != (tally{count: count, sum: sum}) {
+				t.Fatalf("%+v tallyRange(%q) = %+v, want {%d %d}", c, input, got, count, sum)
+			}
+			if got := bigTallyRange(widen([]idRange{rg})[0], base, rule); got.count.Int64() != count || got.sum.Int64() != sum {
+				t.Fatalf("%+v bigTallyRange(%q) = %v, %v, want %d, %d", c, input, got.count, got.sum, count, sum)
+			}
+		}
+	}
+}
+
+func TestInvalidStopsAndExplains(t *testing.T) {
+	var got []string
+	err := Invalid(strings.NewReader("95-115, 1-9999999999999"), AnyRepeat, func(v InvalidID) bool {
+		got = append(got, fmt.Sprintf("%d:%v", v.Range, v))
+		return len(got) < 4
+	})
+	if err != nil {
+		t.Fatalf("Invalid() error = %v", err)
+	}
+	want := []string{`0:99 = "9"×2`, `0:111 = "1"×3`, `1:11 = "1"×2`, `1:22 = "2"×2`}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Invalid() = %q, want %q", got, want)
+	}
+}
+
+func TestInvalidRejectsLongIDs(t *testing.T) {
+	// 19 digits fit in an int64 but are past what can be listed.
+	err := Invalid(strings.NewReader("1111111111111111110-1111111111111111112"), AnyRepeat, func(InvalidID) bool { return true })
+	if err == nil {
+		t.Fatalf("Invalid() of 19-digit IDs succeeded, want an error")
+	}
+}
+
+func TestBigMatchesInt64(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 300; trial++ {
+		num := newNumeral(2 + rng.Intn(35))
+		digits := 1 + rng.Intn(num.maxDigits())
+		rg := idRange{start: rng.Int63n(num.pow[digits])}
+		rg.end = rg.start + rng.Int63n(num.pow[rng.Intn(digits+1)])
+		for _, rule := range testRules {
+			want := num.tallyRange(rg, rule)
+			if want.overflow {
+				continue
+			}
+			got := bigTallyRange(widen([]idRange{rg})[0], num.base, rule)
+			if got.count.Int64() != want.count || got.sum.Int64() != want.sum {
+				t.Fatalf("base %d bigTallyRange(%+v, %v) = %v, %v, want %d, %d", num.base, rg, rule, got.count, got.sum, want.count, want.sum)
+			}
+		}
+	}
+}
+
+func TestWideRanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		part1, part2 string
+	}{
+		// 19 digits fit in an int64 but not in pow10.
+		{"19 digits", "1111111111111111110-1111111111111111112", "0", "1111111111111111111"},
+		{"20 digits", "12345678901234567890-12345678911234567891", "24691357812469135781", "24691357812469135781"},
+		{"overflowing bound", "99999999999999999999999-100000000000000000000001, 11-22",
+			"33", "100000000000000000000032"},
+		// Every ID from 10 to 10^18 that repeats sums to more than an int64.
+		{"overflowing sum", "1-999999999999999999", "495495495540950040450040950", "495990051040401571498681800"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part1, part2, err := aoc.Solve(Solver{}, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Solve() error = %v", err)
+			}
+			if part1.String() != tt.part1 || part2.String() != tt.part2 {
+				t.Fatalf("Solve() = %v, %v, want %s, %s", part1, part2, tt.part1, tt.part2)
+			}
+		})
+	}
+
+	if _, _, err := Solve(strings.NewReader("1-999999999999999999")); err == nil {
+		t.Errorf("Solve() of an overflowing sum succeeded, want an error")
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	for _, rule := range testRules {
+		got, err := ParseRule(rule.String())
+		if err != nil || got != rule {
+			t.Errorf("ParseRule(%q) = %v, %v, want %v", rule.String(), got, err, rule)
+		}
+	}
+	for _, bad := range []string{"", "exactly", "exactly:1", "atleast:x", "prime:2", "some:3"} {
+		if _, err := ParseRule(bad); err == nil {
+			t.Errorf("ParseRule(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestPrimeUsesShortestBlock(t *testing.T) {
+	// 1111 and 111111 are four and six copies of "1", 1212 two of "12".
+	input := "1111-1111, 1212-1212, 111111-111111, 111-111"
+	var got []string
+	if err := Invalid(strings.NewReader(input), Prime(), func(v InvalidID) bool {
+		got = append(got, v.String())
+		return true
+	}); err != nil {
+		t.Fatalf("Invalid() error = %v", err)
+	}
+	if want := []string{`1212 = "12"×2`, `111 = "1"×3`}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Invalid(prime) = %q, want %q", got, want)
+	}
+
+	for _, tt := range []struct {
+		rule       Rule
+		count, sum string
+	}{
+		{Prime(), "2", "1323"},
+		{AnyRepeat, "4", "113545"},
+	} {
+		count, sum, err := Classifier{Rule: tt.rule}.Tally(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Tally() error = %v", err)
+		}
+		if count.String() != tt.count || sum.String() != tt.sum {
+			t.Errorf("%v Tally() = %v, %v, want %s, %s", tt.rule, count, sum, tt.count, tt.sum)
+		}
+	}
+}
+
+func TestBases(t *testing.T) {
+	// 11, 1010 and 1111 repeat a block twice, 111 three times.
+	part1, part2, err := aoc.Solve(Solver{Base: 2}, strings.NewReader("1-1111"))
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if part1.String() != "28" || part2.String() != "35" {
+		t.Fatalf("Solve() = %v, %v, want 28, 35", part1, part2)
+	}
+
+	count, sum, err := Classifier{Base: 36, Rule: Exactly(3)}.Tally(strings.NewReader("zzy-ZZZ, 100000000000000000000-100000000000000000001"))
+	if err != nil {
+		t.Fatalf("Tally() error = %v", err)
+	}
+	if count.String() != "1" || sum.String() != "46655" {
+		t.Fatalf("Tally() = %v, %v, want 1, 46655", count, sum)
+	}
+
+	aoctest.RunParseErrors(t, Solver{Base: 16}, []aoctest.BadInput{
+		{Name: "digit", Input: "a-f, 10-1g", Line: 1, Column: 9},
+	})
+	if _, err := (Solver{Base: 37}).Parse(strings.NewReader("1-2")); err == nil {
+		t.Errorf("Parse() in base 37 succeeded, want an error")
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
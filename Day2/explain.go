@@ -0,0 +1,159 @@
+package day2
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// InvalidID is an invalid ID together with the block it repeats, the
+// shortest one the rule accepts: under AnyRepeat 1111 is "1"×4, under
+// RepeatedTwice it is "11"×2.
+type InvalidID struct {
+	ID int64
+	// Base is the base the ID and Block are written in.
+	Base    int
+	Block   string
+	Repeats int
+	// Range is the index of the input range the ID lies in, Start and End
+	// that range's bounds.
+	Range      int
+	Start, End int64
+}
+
+func (v InvalidID) String() string {
+	return fmt.Sprintf("%s = %q×%d", strconv.FormatInt(v.ID, v.Base), v.Block, v.Repeats)
+}
+
+// Invalid lists the decimal IDs that break rule; see Classifier.Invalid.
+func Invalid(r io.Reader, rule Rule, yield func(InvalidID) bool) error {
+	return Classifier{Rule: rule}.Invalid(r, yield)
+}
+
+// Invalid parses the ranges in r and passes every invalid ID to yield,
+// range by range in input order and in increasing order within each range,
+// until yield returns false. IDs are built from their blocks one at a time,
+// so a huge range costs no more memory than a small one. Only IDs as long
+// as the int64 path handles can be listed, 18 digits in base 10: a range
+// reaching a 19-digit ID is rejected even if that ID fits in an int64.
+func (c Classifier) Invalid(r io.Reader, yield func(InvalidID) bool) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	p, err := parseInput(r, orDecimal(c.Base))
+	if err != nil {
+		return err
+	}
+	if p.wide != nil {
+		return fmt.Errorf("cannot list IDs longer than %d digits", p.num.maxDigits())
+	}
+	for i, rg := range p.ranges {
+		more := p.num.eachInvalid(rg, c.Rule, func(s *blockStream) bool {
+			repeats := c.Rule.repeats(s.repeats)
+			return yield(InvalidID{
+				ID:      s.id(),
+				Base:    p.num.base,
+				Block:   strings.Repeat(strconv.FormatInt(s.block, p.num.base), s.repeats/repeats),
+				Repeats: repeats,
+				Range:   i,
+				Start:   rg.start,
+				End:     rg.end,
+			})
+		})
+		if !more {
+			return nil
+		}
+	}
+	return nil
+}
+
+// blockStream walks the IDs of one length made of repeats copies of a
+// blockLen-digit block that is not itself a repeat, in increasing order of
+// the block. Every ID with a repeated block has exactly one such shortest
+// block, so the streams of one length never overlap.
+type blockStream struct {
+	num        numeral
+	blockLen   int
+	repeats    int
+	multiplier int64
+	block      int64
+	hi         int64
+}
+
+func (n numeral) newBlockStream(start, end int64, blockLen, repeats int) *blockStream {
+	s := &blockStream{
+		num:        n,
+		blockLen:   blockLen,
+		repeats:    repeats,
+		multiplier: n.repeatMultiplier(blockLen, repeats),
+	}
+	s.block, s.hi = n.blockBounds(start, end, s.multiplier, blockLen)
+	s.skip()
+	return s
+}
+
+func (s *blockStream) done() bool { return s.block > s.hi }
+
+func (s *blockStream) id() int64 { return s.block * s.multiplier }
+
+func (s *blockStream) next() {
+	s.block++
+	s.skip()
+}
+
+func (s *blockStream) skip() {
+	for !s.done() && s.num.isRepeat(s.block, s.blockLen) {
+		s.block++
+	}
+}
+
+// isRepeat reports whether the length-digit number v repeats a shorter block.
+// A d-digit block repeated length/d times is the block times
+// repeatMultiplier(d, length/d), and any multiple of that with length digits
+// has a d-digit quotient.
+func (n numeral) isRepeat(v int64, length int) bool {
+	for _, d := range properDivisors(length) {
+		if v%n.repeatMultiplier(d, length/d) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// eachInvalid calls fn with a stream positioned on each ID in rg that breaks
+// rule, in increasing order, and reports whether fn always returned true.
+func (n numeral) eachInvalid(rg idRange, rule Rule, fn func(*blockStream) bool) bool {
+	for length := 2; length <= n.maxDigits() && n.pow[length-1] <= rg.end; length++ {
+		segmentStart := maxInt64(rg.start, n.pow[length-1])
+		segmentEnd := minInt64(rg.end, n.pow[length]-1)
+		if segmentStart > segmentEnd {
+			continue
+		}
+
+		var streams []*blockStream
+		for _, d := range properDivisors(length) {
+			if rule.repeats(length/d) > 0 {
+				streams = append(streams, n.newBlockStream(segmentStart, segmentEnd, d, length/d))
+			}
+		}
+
+		// Merging the streams by smallest next ID lists the segment in order.
+		for {
+			var lowest *blockStream
+			for _, s := range streams {
+				if !s.done() && (lowest == nil || s.id() < lowest.id()) {
+					lowest = s
+				}
+			}
+			if lowest == nil {
+				break
+			}
+			if !fn(lowest) {
+				return false
+			}
+			lowest.next()
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package day2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type ruleKind uint8
+
+const (
+	ruleExactly ruleKind = iota + 1
+	ruleAtLeast
+	rulePrime
+)
+
+// Rule decides how many times a block must repeat for an ID to be invalid.
+// Exactly and AtLeast accept an ID if any way of cutting it into equal
+// blocks has an accepted count, so 1111 is "11"×2 as well as "1"×4. Prime
+// looks only at the ID's primitive count, the copies of its shortest block:
+// every ID with two or more copies can be cut into a prime number of
+// blocks, so otherwise Prime would be AtLeast(2). Under Prime, 111 = "1"×3
+// is invalid but 1111 = "1"×4 is valid. The zero Rule is not valid; build
+// one with Exactly, AtLeast or Prime.
+type Rule struct {
+	kind ruleKind
+	k    int
+}
+
+// Exactly accepts blocks repeated exactly k times, k >= 2.
+func Exactly(k int) Rule { return Rule{kind: ruleExactly, k: k} }
+
+// AtLeast accepts blocks repeated k or more times, k >= 2.
+func AtLeast(k int) Rule { return Rule{kind: ruleAtLeast, k: k} }
+
+// Prime accepts IDs whose shortest block is repeated a prime number of
+// times.
+func Prime() Rule { return Rule{kind: rulePrime} }
+
+var (
+	// RepeatedTwice is part 1: some block of digits written exactly twice.
+	RepeatedTwice = Exactly(2)
+	// AnyRepeat is part 2: some block written two or more times.
+	AnyRepeat = AtLeast(2)
+)
+
+// ParseRule reads a rule written as String writes it: "exactly:K",
+// "atleast:K" or "prime".
+func ParseRule(s string) (Rule, error) {
+	name, arg, hasArg := strings.Cut(s, ":")
+	var r Rule
+	switch name {
+	case "exactly":
+		r.kind = ruleExactly
+	case "atleast":
+		r.kind = ruleAtLeast
+	case "prime":
+		if hasArg {
+			return Rule{}, fmt.Errorf("rule %q takes no count", s)
+		}
+		return Prime(), nil
+	default:
+		return Rule{}, fmt.Errorf("unknown rule %q (want exactly:K, atleast:K or prime)", s)
+	}
+	k, err := strconv.Atoi(arg)
+	if err != nil {
+		return Rule{}, fmt.Errorf("rule %q needs a repeat count", s)
+	}
+	r.k = k
+	return r, r.Validate()
+}
+
+// Validate reports whether r is one of the rules above with a usable count.
+func (r Rule) Validate() error {
+	switch r.kind {
+	case ruleExactly, ruleAtLeast:
+		if r.k < 2 {
+			return fmt.Errorf("rule %s: a block must repeat at least twice", r)
+		}
+	case rulePrime:
+	default:
+		return fmt.Errorf("invalid rule")
+	}
+	return nil
+}
+
+func (r Rule) String() string {
+	switch r.kind {
+	case ruleExactly:
+		return fmt.Sprintf("exactly:%d", r.k)
+	case ruleAtLeast:
+		return fmt.Sprintf("atleast:%d", r.k)
+	case rulePrime:
+		return "prime"
+	}
+	return "invalid"
+}
+
+// allows reports whether r accepts a block repeated n times.
+func (r Rule) allows(n int) bool {
+	switch r.kind {
+	case ruleExactly:
+		return n == r.k
+	case ruleAtLeast:
+		return n >= r.k
+	case rulePrime:
+		return isPrime(n)
+	}
+	return false
+}
+
+// repeats returns the largest repeat count r accepts for an ID made of n
+// copies of its shortest block, or 0 if it accepts none. Such an ID is m
+// copies of a longer block for every divisor m of n, so the answer is the
+// largest accepted divisor; taking the largest explains the ID by its
+// shortest acceptable block. Prime only considers n itself.
+func (r Rule) repeats(n int) int {
+	if r.kind == rulePrime {
+		if isPrime(n) {
+			return n
+		}
+		return 0
+	}
+	for m := n; m >= 2; m-- {
+		if n%m == 0 && r.allows(m) {
+			return m
+		}
+	}
+	return 0
+}
+
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
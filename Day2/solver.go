@@ -0,0 +1,40 @@
+package day2
+
+import (
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's parser and sums to the aoc.Solver interface.
+// Base is the numeral base the IDs are written in; zero means 10.
+type Solver struct {
+	Base int
+}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 2 }
+
+// Parse implements aoc.Solver.
+func (s Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	base := orDecimal(s.Base)
+	if err := validBase(base); err != nil {
+		return nil, err
+	}
+	p, err := parseInput(r, base)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	return p.sumInvalid(RepeatedTwice), nil
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	return p.sumInvalid(AnyRepeat), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,25 @@
+package day2
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// synthetic generates scale*10 decimal ID ranges spread over every digit
+// length the int64 path handles.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	var buf bytes.Buffer
+	for i := 0; i < scale*10; i++ {
+		digits := 2 + rng.Intn(decimal.maxDigits()-2)
+		start := decimal.pow[digits-1] + rng.Int63n(decimal.pow[digits]-decimal.pow[digits-1])
+		end := start + rng.Int63n(1_000_000)
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, "%d-%d", start, end)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
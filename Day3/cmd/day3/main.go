@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"aoc25/Day3"
+	"aoc25/aoc"
+)
+
+// pickList collects the values of a repeated -pick flag.
+type pickList []int
+
+func (p *pickList) String() string {
+	return fmt.Sprint(*p)
+}
+
+func (p *pickList) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("want a positive digit count, got %q", s)
+	}
+	*p = append(*p, n)
+	return nil
+}
+
+func main() {
+	var picks pickList
+	flag.Var(&picks, "pick", "instead of solving, print each bank's best `n`-digit selection; repeatable")
+	var opts day3.Options
+	flag.BoolVar(&opts.Minimize, "min", false, "make -pick choose the smallest number without a leading zero")
+	flag.IntVar(&opts.MinGap, "gap", 0, "least distance between the positions -pick chooses")
+	flag.IntVar(&opts.MaxUses, "max-uses", 0, "how often -pick may choose each digit value, 0 for no limit")
+	flag.Parse()
+	if len(picks) == 0 {
+		aoc.MainArgs(day3.Solver{}, flag.Args())
+		return
+	}
+
+	path := aoc.InputPath(3, flag.Args())
+	for i, n := range picks {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := writePicks(path, n, opts); err != nil {
+			aoc.Fatal(path, err)
+		}
+	}
+}
+
+// writePicks prints a table of the n-digit selection under opts of every
+// bank in the file at path, with the digits' positions and the total.
+func writePicks(path string, n int, opts day3.Options) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fmt.Printf("pick %d\n", n)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "line\tdigits\tindices")
+	total := new(big.Int)
+	err = opts.PickLines(file, n, func(line int, sel day3.Selection) bool {
+		indices := make([]string, len(sel.Indices))
+		for i, idx := range sel.Indices {
+			indices[i] = strconv.Itoa(idx)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", line, sel.Digits, strings.Join(indices, ","))
+		total.Add(total, sel.Value)
+		return true
+	})
+	if err != nil {
+		tw.Flush()
+		return err
+	}
+	fmt.Fprintf(tw, "total\t%s\t\n", total)
+	return tw.Flush()
+}
@@ -0,0 +1,115 @@
+// Package day3 solves Advent of Code 2025 day 3: Lobby.
+package day3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+const part1Digits = 2
+const part2Digits = 12
+
+// maxInt64Digits is the longest pick that always fits in an int64.
+const maxInt64Digits = 18
+
+func Solve(r io.Reader) (int64, int64, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	totalPart1, err := sumMaxValues(lines, part1Digits)
+	if err != nil {
+		return 0, 0, err
+	}
+	totalPart2, err := sumMaxValues(lines, part2Digits)
+	if err != nil {
+		return 0, 0, err
+	}
+	return totalPart1, totalPart2, nil
+}
+
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	err := scanBanks(r, func(_ int, line string) bool {
+		lines = append(lines, line)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// scanBanks reads r one line at a time and passes each non-empty line
+// and its line number to fn until fn returns false.
+func scanBanks(r io.Reader, fn func(lineNumber int, line string) bool) error {
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if !fn(lineNumber, line) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func sumMaxValues(lines []string, pick int) (int64, error) {
+	var total int64
+	for _, line := range lines {
+		val, err := maxValueForDigits(line, pick)
+		if err != nil {
+			return 0, err
+		}
+		total += val
+	}
+	return total, nil
+}
+
+// maxValueForDigits is Pick for the puzzle's parts, whose picks fit in an
+// int64.
+func maxValueForDigits(line string, pick int) (int64, error) {
+	if pick > maxInt64Digits {
+		return 0, fmt.Errorf("pick %d overflows int64", pick)
+	}
+	indices, err := pickMax(line, pick)
+	if err != nil {
+		return 0, err
+	}
+
+	var val int64
+	for _, i := range indices {
+		val = val*10 + int64(line[i]-'0')
+	}
+	return val, nil
+}
+
+// pickMax returns the positions of the pick digits of line that, kept in
+// order, form the largest number. A stack keeps the best prefix so far: a
+// digit pops every smaller digit before it as long as enough digits remain
+// to refill the stack.
+func pickMax(line string, pick int) ([]int, error) {
+	if pick <= 0 {
+		return nil, fmt.Errorf("invalid pick %d", pick)
+	}
+	if len(line) < pick {
+		return nil, fmt.Errorf("line %q shorter than %d digits", line, pick)
+	}
+
+	stack := make([]int, 0, pick)
+	for i := 0; i < len(line); i++ {
+		remaining := len(line) - i
+		for len(stack) > 0 && len(stack)+remaining > pick && line[stack[len(stack)-1]] < line[i] {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) < pick {
+			stack = append(stack, i)
+		}
+	}
+	return stack, nil
+}
@@ -0,0 +1,221 @@
+package day3
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"aoc25/aoc"
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "357", Part2: "3121910778619"},
+	})
+}
+
+// bruteForcePick tries every choice of n digits from bank.
+func bruteForcePick(bank string, n int) string {
+	best := ""
+	for mask := 0; mask < 1<<len(bank); mask++ {
+		if bits.OnesCount(uint(mask)) != n {
+			continue
+		}
+		var sb strings.Builder
+		for i := range bank {
+			if mask&(1<<i) != 0 {
+				sb.WriteByte(bank[i])
+			}
+		}
+		if s := sb.String(); s > best {
+			best = s
+		}
+	}
+	return best
+}
+
+func TestPickMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 500; trial++ {
+		bank := make([]byte, 1+rng.Intn(12))
+		for i := range bank {
+			bank[i] = byte('0' + rng.Intn(1+rng.Intn(10)))
+		}
+		n := 1 + rng.Intn(len(bank))
+
+		sel, err := Pick(string(bank), n)
+		if err != nil {
+			t.Fatalf("Pick(%q, %d) error = %v", bank, n, err)
+		}
+		want := bruteForcePick(string(bank), n)
+		wantValue, _ := new(big.Int).SetString(want, 10)
+		if sel.Digits != want || sel.Value.Cmp(wantValue) != 0 {
+			t.Fatalf("Pick(%q, %d) = %q (%v), want %q", bank, n, sel.Digits, sel.Value, want)
+		}
+		for i, idx := range sel.Indices {
+			if bank[idx] != sel.Digits[i] || (i > 0 && idx <= sel.Indices[i-1]) {
+				t.Fatalf("Pick(%q, %d) indices %v do not spell %q in order", bank, n, sel.Indices, sel.Digits)
+			}
+		}
+	}
+}
+
+// bruteForceOptions tries every choice of n digits from bank and returns
+// the best one allowed by o, or "" if none is.
+func bruteForceOptions(bank string, n int, o Options) string {
+	best := ""
+	for mask := 0; mask < 1<<len(bank); mask++ {
+		if bits.OnesCount(uint(mask)) != n {
+			continue
+		}
+		var sb strings.Builder
+		var uses [10]int
+		last, ok := -1, true
+		for i := range bank {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			uses[bank[i]-'0']++
+			if (last >= 0 && i-last < o.MinGap) || (o.MaxUses > 0 && uses[bank[i]-'0'] > o.MaxUses) {
+				ok = false
+			}
+			last = i
+			sb.WriteByte(bank[i])
+		}
+		s := sb.String()
+		if !ok || (o.Minimize && n > 1 && s[0] == '0') {
+			continue
+		}
+		if best == "" || (o.Minimize && s < best) || (!o.Minimize && s > best) {
+			best = s
+		}
+	}
+	return best
+}
+
+func TestOptionsMatchBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 3000; trial++ {
+		bank := make([]byte, 1+rng.Intn(11))
+		for i := range bank {
+			bank[i] = byte('0' + rng.Intn(1+rng.Intn(10)))
+		}
+		n := 1 + rng.Intn(len(bank))
+		o := Options{Minimize: rng.Intn(2) == 0}
+		if rng.Intn(2) == 0 {
+			o.MinGap = rng.Intn(4)
+		}
+		if rng.Intn(2) == 0 {
+			o.MaxUses = rng.Intn(4)
+		}
+
+		want := bruteForceOptions(string(bank), n, o)
+		sel, err := o.Pick(string(bank), n)
+		if want == "" {
+			if err == nil {
+				t.Fatalf("%+v Pick(%q, %d) = %q, want an error", o, bank, n, sel.Digits)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%+v Pick(%q, %d) error = %v, want %q", o, bank, n, err, want)
+		}
+		if sel.Digits != want {
+			t.Fatalf("%+v Pick(%q, %d) = %q, want %q", o, bank, n, sel.Digits, want)
+		}
+		for i, idx := range sel.Indices {
+			if bank[idx] != sel.Digits[i] || (i > 0 && idx-sel.Indices[i-1] < max(o.MinGap, 1)) {
+				t.Fatalf("%+v Pick(%q, %d) indices %v break the gap or do not spell %q", o, bank, n, sel.Indices, sel.Digits)
+			}
+		}
+	}
+}
+
+func TestPickMinLeadingZero(t *testing.T) {
+	// A lone 0 is not a leading zero.
+	for n, want := range map[int]string{1: "0", 2: "10", 3: "109"} {
+		sel, err := Options{Minimize: true}.Pick("0090109", n)
+		if err != nil || sel.Digits != want {
+			t.Errorf("Pick(%d) = %q, %v, want %q", n, sel.Digits, err, want)
+		}
+	}
+	if _, err := (Options{Minimize: true}).Pick("0009", 2); err == nil {
+		t.Errorf("Pick() with only a trailing non-zero digit succeeded, want an error")
+	}
+}
+
+func TestPickRejectsNonDigits(t *testing.T) {
+	for _, o := range []Options{{}, {MinGap: 2}, {Minimize: true}, {MaxUses: 1}} {
+		var pe *aoc.ParseError
+		if _, err := o.Pick("1a2", 2); !errors.As(err, &pe) || pe.Column != 2 {
+			t.Errorf("%+v Pick(%q) error = %v, want a ParseError at column 2", o, "1a2", err)
+		}
+	}
+}
+
+func TestPickBeyondInt64(t *testing.T) {
+	bank := strings.Repeat("9", 15) + strings.Repeat("18", 10)
+	sel, err := Pick(bank, 30)
+	if err != nil {
+		t.Fatalf("Pick() error = %v", err)
+	}
+	// Five of the ones have to go, and the earliest ones free up the most.
+	want := strings.Repeat("9", 15) + "88888" + strings.Repeat("18", 5)
+	if sel.Digits != want || sel.Value.String() != want {
+		t.Fatalf("Pick() = %q (%v), want %q", sel.Digits, sel.Value, want)
+	}
+	if _, err := maxValueForDigits(bank, 30); err == nil {
+		t.Errorf("maxValueForDigits(30) succeeded, want an overflow error")
+	}
+}
+
+func TestPickLines(t *testing.T) {
+	var lines []int
+	var digits []string
+	err := PickLines(strings.NewReader("987654321111111\n\n818181911112111\n12\n"), 2, func(line int, sel Selection) bool {
+		lines = append(lines, line)
+		digits = append(digits, sel.Digits)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("PickLines() error = %v", err)
+	}
+	if !reflect.DeepEqual(lines, []int{1, 3, 4}) || !reflect.DeepEqual(digits, []string{"98", "92", "12"}) {
+		t.Fatalf("PickLines() = %v %q, want [1 3 4] [98 92 12]", lines, digits)
+	}
+
+	err = PickLines(strings.NewReader("987654321111111\n\n123\n"), 4, func(int, Selection) bool { return true })
+	var pe *aoc.ParseError
+	if !errors.As(err, &pe) || pe.Line != 3 {
+		t.Fatalf("PickLines() of a short bank error = %v, want a parse error on line 3", err)
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
+
+func BenchmarkPickConstrained(b *testing.B) {
+	banks, err := readLines(bytes.NewReader(synthetic(1)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	o := Options{MinGap: 3, MaxUses: 2}
+	for i := 0; i < b.N; i++ {
+		for _, bank := range banks {
+			if _, err := o.Pick(bank, 12); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package day3
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"aoc25/aoc"
+)
+
+// Selection is a number read from a bank by keeping some of its digits in
+// order.
+type Selection struct {
+	// Digits is the number as written.
+	Digits string
+	// Indices are the 0-based positions in the bank the digits came from.
+	Indices []int
+	Value   *big.Int
+}
+
+// Options selects how digits are picked. The zero Options picks the largest
+// number with no constraints, which is what the puzzle asks for.
+type Options struct {
+	// Minimize picks the smallest number instead. Its first digit is not 0
+	// unless it is the only digit.
+	Minimize bool
+	// MinGap is the least distance between the positions of consecutive
+	// picked digits; 0 and 1 allow neighbours.
+	MinGap int
+	// MaxUses limits how many times each digit value may be picked; 0 means
+	// no limit.
+	MaxUses int
+}
+
+// Pick selects the n digits of bank that form the largest number. It fails
+// if n is not positive, bank has fewer than n digits or holds anything but
+// digits.
+func Pick(bank string, n int) (Selection, error) {
+	return Options{}.Pick(bank, n)
+}
+
+// PickLines is Options.PickLines for the largest numbers.
+func PickLines(r io.Reader, n int, yield func(line int, sel Selection) bool) error {
+	return Options{}.PickLines(r, n, yield)
+}
+
+// Pick selects the n digits of bank that form the best number under o. It
+// fails if n is not positive, bank holds anything but digits or no n digits
+// of bank satisfy o.
+func (o Options) Pick(bank string, n int) (Selection, error) {
+	if o.MinGap < 0 || o.MaxUses < 0 {
+		return Selection{}, fmt.Errorf("invalid options %+v", o)
+	}
+	if err := checkDigits(bank); err != nil {
+		return Selection{}, err
+	}
+	var indices []int
+	var err error
+	if o == (Options{}) {
+		indices, err = pickMax(bank, n)
+	} else {
+		indices, err = newPicker(bank, o).pick(n)
+	}
+	if err != nil {
+		return Selection{}, err
+	}
+	digits := make([]byte, len(indices))
+	for i, idx := range indices {
+		digits[i] = bank[idx]
+	}
+	value, ok := new(big.Int).SetString(string(digits), 10)
+	if !ok {
+		return Selection{}, fmt.Errorf("invalid digits %q", digits)
+	}
+	return Selection{Digits: string(digits), Indices: indices, Value: value}, nil
+}
+
+// PickLines reads the banks in r and passes each one's selection, with its
+// line number, to yield until yield returns false. A bank with no valid
+// selection is reported as a parse error on its line.
+func (o Options) PickLines(r io.Reader, n int, yield func(line int, sel Selection) bool) error {
+	if n <= 0 {
+		return fmt.Errorf("invalid pick %d", n)
+	}
+	var pickErr error
+	err := scanBanks(r, func(lineNumber int, bank string) bool {
+		sel, err := o.Pick(bank, n)
+		if err != nil {
+			pickErr = aoc.AtLine(err, lineNumber, 0, bank)
+			return false
+		}
+		return yield(lineNumber, sel)
+	})
+	if err != nil {
+		return err
+	}
+	return pickErr
+}
+
+// checkDigits reports the first character of bank that is not a digit.
+// The error carries its column and is placed on a line by the caller.
+func checkDigits(bank string) error {
+	for i := 0; i < len(bank); i++ {
+		if bank[i] < '0' || bank[i] > '9' {
+			return aoc.ParseErrorf(0, i+1, "", "invalid digit %q", bank[i])
+		}
+	}
+	return nil
+}
+
+// picker chooses digits one at a time, best digit first, keeping a choice
+// only if the rest of the pick can still be completed after it. Taking the
+// earliest occurrence of a digit is never worse than a later one: whatever
+// completes the later one also completes the earlier.
+type picker struct {
+	bank string
+	opts Options
+	gap  int
+	// next[i][d] is the first position at or after i holding digit d, or
+	// len(bank) if there is none.
+	next [][10]int
+	// suffix[i][d] counts the digit d at positions i and later.
+	suffix [][10]int
+	memo   map[feasibleKey]bool
+}
+
+type feasibleKey struct {
+	start int
+	left  int
+	used  [10]uint8
+}
+
+func newPicker(bank string, o Options) *picker {
+	p := &picker{
+		bank:   bank,
+		opts:   o,
+		gap:    max(o.MinGap, 1),
+		next:   make([][10]int, len(bank)+1),
+		suffix: make([][10]int, len(bank)+1),
+		memo:   map[feasibleKey]bool{},
+	}
+	for d := range p.next[len(bank)] {
+		p.next[len(bank)][d] = len(bank)
+	}
+	for i := len(bank) - 1; i >= 0; i-- {
+		p.next[i] = p.next[i+1]
+		p.suffix[i] = p.suffix[i+1]
+		d := bank[i] - '0'
+		p.next[i][d] = i
+		p.suffix[i][d]++
+	}
+	return p
+}
+
+func (p *picker) pick(n int) ([]int, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid pick %d", n)
+	}
+	if p.opts.MaxUses > 255 {
+		// No bank uses a digit more often than it has digits, and used
+		// counts are kept in bytes.
+		p.opts.MaxUses = 0
+	}
+
+	var used [10]uint8
+	indices := make([]int, 0, n)
+	start := 0
+	for left := n; left > 0; left-- {
+		chosen := -1
+		for k := 0; k < 10; k++ {
+			d := 9 - k
+			if p.opts.Minimize {
+				d = k
+				if d == 0 && len(indices) == 0 && n > 1 {
+					continue
+				}
+			}
+			q := p.next[start][d]
+			if q == len(p.bank) || !p.allowed(used, d) {
+				continue
+			}
+			used[d]++
+			if p.feasible(q+p.gap, left-1, used) {
+				chosen = q
+				break
+			}
+			used[d]--
+		}
+		if chosen == -1 {
+			return nil, fmt.Errorf("no %d digits of %q satisfy %+v", n, p.bank, p.opts)
+		}
+		indices = append(indices, chosen)
+		start = chosen + p.gap
+	}
+	return indices, nil
+}
+
+func (p *picker) allowed(used [10]uint8, d int) bool {
+	return p.opts.MaxUses == 0 || int(used[d]) < p.opts.MaxUses
+}
+
+// feasible reports whether left more digits can be picked at or after
+// start once the digits counted in used have been picked.
+func (p *picker) feasible(start, left int, used [10]uint8) bool {
+	if left == 0 {
+		return true
+	}
+	if start >= len(p.bank) || (len(p.bank)-start+p.gap-1)/p.gap < left {
+		return false
+	}
+	if p.opts.MaxUses == 0 {
+		// Every gap-th position from start will do.
+		return true
+	}
+	available := 0
+	for d := 0; d < 10; d++ {
+		available += min(p.opts.MaxUses-int(used[d]), p.suffix[start][d])
+	}
+	if available < left {
+		return false
+	}
+
+	key := feasibleKey{start: start, left: left, used: used}
+	if ok, seen := p.memo[key]; seen {
+		return ok
+	}
+	ok := false
+	for d := 0; d < 10 && !ok; d++ {
+		q := p.next[start][d]
+		if q == len(p.bank) || !p.allowed(used, d) {
+			continue
+		}
+		used[d]++
+		ok = p.feasible(q+p.gap, left-1, used)
+		used[d]--
+	}
+	p.memo[key] = ok
+	return ok
+}
@@ -0,0 +1,46 @@
+package day3
+
+import (
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's parser and digit picking to the aoc.Solver
+// interface.
+type Solver struct{}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 3 }
+
+// Parse implements aoc.Solver.
+func (Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{lines: lines}, nil
+}
+
+type puzzle struct {
+	lines []string
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	total, err := sumMaxValues(p.lines, part1Digits)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(total), nil
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	total, err := sumMaxValues(p.lines, part2Digits)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(total), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,19 @@
+package day3
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+// synthetic generates scale*20 battery banks of 100 digits each.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	var buf bytes.Buffer
+	for i := 0; i < scale*20; i++ {
+		for j := 0; j < 100; j++ {
+			buf.WriteByte(byte('1' + rng.Intn(9)))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,203 @@
+package day4
+
+import "math/bits"
+
+// bitGrid packs a grid one bit per cell, row by row, with each row padded
+// to a whole number of 64-bit words. Bit c%64 of word c/64 of a row is
+// column c; padding bits are always clear.
+type bitGrid struct {
+	rows, cols int
+	// stride is the number of words per row.
+	stride int
+	words  []uint64
+}
+
+func newBitGrid(cols int) *bitGrid {
+	return &bitGrid{cols: cols, stride: (cols + 63) / 64}
+}
+
+// addRow appends an empty row and returns its words.
+func (g *bitGrid) addRow() []uint64 {
+	g.rows++
+	g.words = append(g.words, make([]uint64, g.stride)...)
+	return g.row(g.rows - 1)
+}
+
+func (g *bitGrid) row(r int) []uint64 {
+	return g.words[r*g.stride : (r+1)*g.stride]
+}
+
+func (g *bitGrid) get(r, c int) bool {
+	return g.words[r*g.stride+c/64]&(1<<(c%64)) != 0
+}
+
+// packGrid converts a grid of booleans, all rows the same length.
+func packGrid(grid [][]bool) *bitGrid {
+	g := newBitGrid(len(grid[0]))
+	for _, cells := range grid {
+		row := g.addRow()
+		for c, occ := range cells {
+			if occ {
+				row[c/64] |= 1 << (c % 64)
+			}
+		}
+	}
+	return g
+}
+
+// bitCounter counts neighbours for a whole row at once. A word of
+// neighbours at one offset is the row above, at or below shifted a column
+// either way; adding those words together bit-sliced, with the count kept
+// in four planes of bits, counts the neighbours of 64 cells at a time.
+type bitCounter struct {
+	// offsets index the shifted words: 3*(dr+1) + dc+1.
+	offsets []int
+	// rowOffsets are the distinct dr of the neighbourhood.
+	rowOffsets []int
+	threshold  int
+}
+
+// bitCounter returns a counter for e, or false if e's neighbourhood
+// reaches further than the eight surrounding cells or its threshold needs
+// more than four bits.
+func (e Engine) bitCounter() (bitCounter, bool) {
+	bc := bitCounter{threshold: e.threshold()}
+	if bc.threshold > 15 {
+		return bitCounter{}, false
+	}
+	var seenRow [3]bool
+	for _, off := range e.neighborhood() {
+		dr, dc := off[0], off[1]
+		if dr < -1 || dr > 1 || dc < -1 || dc > 1 {
+			return bitCounter{}, false
+		}
+		bc.offsets = append(bc.offsets, 3*(dr+1)+dc+1)
+		if !seenRow[dr+1] {
+			seenRow[dr+1] = true
+			bc.rowOffsets = append(bc.rowOffsets, dr)
+		}
+	}
+	return bc, true
+}
+
+// sparse sets dst to the occupied cells of row r of g with fewer than
+// threshold neighbours and returns how many there are.
+func (bc bitCounter) sparse(g *bitGrid, r int, dst []uint64) int {
+	var rows [3][]uint64
+	for dr := -1; dr <= 1; dr++ {
+		if r+dr >= 0 && r+dr < g.rows {
+			rows[dr+1] = g.row(r + dr)
+		}
+	}
+	occupied := rows[1]
+	n := 0
+	for w := range dst {
+		if occupied[w] == 0 {
+			dst[w] = 0
+			continue
+		}
+		var shifted [9]uint64
+		for i, row := range rows {
+			if row == nil {
+				continue
+			}
+			x := row[w]
+			var prev, next uint64
+			if w > 0 {
+				prev = row[w-1]
+			}
+			if w+1 < len(row) {
+				next = row[w+1]
+			}
+			shifted[3*i] = x<<1 | prev>>63
+			shifted[3*i+1] = x
+			shifted[3*i+2] = x>>1 | next<<63
+		}
+
+		var s0, s1, s2, s3 uint64
+		for _, i := range bc.offsets {
+			carry := shifted[i]
+			s0, carry = s0^carry, s0&carry
+			s1, carry = s1^carry, s1&carry
+			s2, carry = s2^carry, s2&carry
+			s3 |= carry
+		}
+		dst[w] = occupied[w] & below(s0, s1, s2, s3, bc.threshold)
+		n += bits.OnesCount64(dst[w])
+	}
+	return n
+}
+
+// below compares each bit-sliced count s3s2s1s0 with t, setting the bits
+// of the counts that are smaller.
+func below(s0, s1, s2, s3 uint64, t int) uint64 {
+	planes := [4]uint64{s0, s1, s2, s3}
+	var less uint64
+	equal := ^uint64(0)
+	for k := 3; k >= 0; k-- {
+		if t&(1<<k) != 0 {
+			less |= equal &^ planes[k]
+			equal &= planes[k]
+		} else {
+			equal &^= planes[k]
+		}
+	}
+	return less
+}
+
+// firstRound counts the cells the first round of erosion removes.
+func (bc bitCounter) firstRound(g *bitGrid) int {
+	mask := make([]uint64, g.stride)
+	n := 0
+	for r := 0; r < g.rows; r++ {
+		n += bc.sparse(g, r, mask)
+	}
+	return n
+}
+
+// erode erodes a copy of g to a standstill and returns the number of cells
+// each round removed. After the first round only rows next to a row that
+// lost cells can change, so only those are recounted.
+func (bc bitCounter) erode(g *bitGrid) []int {
+	work := &bitGrid{rows: g.rows, cols: g.cols, stride: g.stride, words: append([]uint64(nil), g.words...)}
+	masks := make([]uint64, len(work.words))
+	// dirty[r] is the round in which row r was last queued.
+	dirty := make([]int, g.rows)
+	rows := make([]int, g.rows)
+	for r := range rows {
+		rows[r] = r
+	}
+
+	var perRound []int
+	var changed []int
+	for round := 1; len(rows) > 0; round++ {
+		removed := 0
+		changed = changed[:0]
+		for _, r := range rows {
+			if n := bc.sparse(work, r, masks[r*g.stride:(r+1)*g.stride]); n > 0 {
+				removed += n
+				changed = append(changed, r)
+			}
+		}
+		if removed == 0 {
+			break
+		}
+		perRound = append(perRound, removed)
+
+		rows = rows[:0]
+		for _, r := range changed {
+			row, mask := work.row(r), masks[r*g.stride:(r+1)*g.stride]
+			for w := range row {
+				row[w] &^= mask[w]
+			}
+			// The cells that count row r's as neighbours are in row r-dr.
+			for _, dr := range bc.rowOffsets {
+				if nr := r - dr; nr >= 0 && nr < g.rows && dirty[nr] != round {
+					dirty[nr] = round
+					rows = append(rows, nr)
+				}
+			}
+		}
+	}
+	return perRound
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"aoc25/Day4"
+	"aoc25/aoc"
+)
+
+func main() {
+	neighbors := flag.String("neighbors", "8", "neighbourhood: 4, 8 or offsets `dr,dc;dr,dc;...`")
+	threshold := flag.Int("threshold", 4, "remove rolls with fewer than `n` neighbours")
+	rounds := flag.Bool("rounds", false, "instead of solving, print how many rolls each round removes")
+	export := flag.String("export", "", "instead of solving, write the erosion frames as `text`, ansi or gif")
+	output := flag.String("o", "", "write -export to `file` instead of standard output")
+	cell := flag.Int("cell", 4, "size in pixels of a cell in -export gif")
+	delay := flag.Duration("delay", 200*time.Millisecond, "time each -export ansi or gif frame is shown")
+	flag.Parse()
+	if *export != "" && *export != "text" && *export != "ansi" && *export != "gif" {
+		fmt.Fprintf(os.Stderr, "unknown export format %q (want text, ansi or gif)\n", *export)
+		os.Exit(2)
+	}
+	if *cell < 1 {
+		fmt.Fprintf(os.Stderr, "-cell wants a positive size, got %d\n", *cell)
+		os.Exit(2)
+	}
+	hood, err := day4.ParseNeighborhood(*neighbors)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "-neighbors: %v\n", err)
+		os.Exit(2)
+	}
+	if *threshold < 1 {
+		fmt.Fprintf(os.Stderr, "-threshold wants a positive count, got %d\n", *threshold)
+		os.Exit(2)
+	}
+	engine := day4.Engine{Neighborhood: hood, Threshold: *threshold}
+	if !*rounds && *export == "" {
+		aoc.MainArgs(day4.Solver{Engine: engine}, flag.Args())
+		return
+	}
+
+	path := aoc.InputPath(4, flag.Args())
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+	erosion, err := engine.Erode(file)
+	if err != nil {
+		aoc.Fatal(path, err)
+	}
+	if *export != "" {
+		if err := writeExport(erosion, *export, *output, *cell, *delay); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	for i, n := range erosion.PerRound {
+		fmt.Printf("round %d: %d\n", i+1, n)
+	}
+	fmt.Printf("%d rolls removed in %d rounds\n", erosion.Total(), len(erosion.PerRound))
+}
+
+// writeExport writes the frames of erosion in format to the file at path,
+// or to standard output if path is empty.
+func writeExport(erosion day4.Erosion, format, path string, cell int, delay time.Duration) (err error) {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := file.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		w = file
+	}
+	switch format {
+	case "ansi":
+		return erosion.WriteANSI(w, delay)
+	case "gif":
+		return erosion.WriteGIF(w, cell, delay)
+	}
+	return erosion.WriteText(w)
+}
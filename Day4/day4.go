@@ -0,0 +1,65 @@
+// Package day4 solves Advent of Code 2025 day 4: Printing Department.
+package day4
+
+import (
+	"bufio"
+	"io"
+
+	"aoc25/aoc"
+)
+
+func Solve(r io.Reader) (int, int, error) {
+	grid, err := readGrid(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	part1 := countAccessible(grid)
+	part2 := totalRemovable(grid)
+
+	return part1, part2, nil
+}
+
+// readGrid reads a grid of @ (a roll) and . (empty) cells into a bitGrid.
+func readGrid(r io.Reader) (*bitGrid, error) {
+	scanner := bufio.NewScanner(r)
+	var grid *bitGrid
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if grid == nil {
+			grid = newBitGrid(len(line))
+		} else if len(line) != grid.cols {
+			return nil, aoc.ParseErrorf(lineNumber, min(len(line), grid.cols)+1, line,
+				"inconsistent row length: got %d want %d", len(line), grid.cols)
+		}
+		row := grid.addRow()
+		for j := 0; j < len(line); j++ {
+			if line[j] == '@' {
+				row[j/64] |= 1 << (j % 64)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if grid == nil {
+		return nil, &aoc.ParseError{Msg: "empty grid"}
+	}
+	return grid, nil
+}
+
+// countAccessible counts the rolls with fewer than four neighbours, the
+// ones the first round of erosion removes.
+func countAccessible(grid *bitGrid) int {
+	return Engine{}.accessible(grid)
+}
+
+// totalRemovable counts the rolls erosion removes before it stops.
+func totalRemovable(grid *bitGrid) int {
+	return Engine{}.removable(grid)
+}
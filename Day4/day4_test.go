@@ -0,0 +1,272 @@
+package day4
+
+import (
+	"bytes"
+	"embed"
+	"image/gif"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "13", Part2: "43"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "empty", Input: "\n\n", Line: 0, Column: 0},
+		{Name: "short row", Input: "..@@\n.@\n", Line: 2, Column: 3},
+		{Name: "long row", Input: "..@@\n.@.@.\n", Line: 2, Column: 5},
+	})
+}
+
+// naiveErosion rescans the whole grid every round.
+func naiveErosion(grid [][]bool, offsets Neighborhood, threshold int) (perRound []int, round [][]int) {
+	rows, cols := len(grid), len(grid[0])
+	current := make([][]bool, rows)
+	round = make([][]int, rows)
+	for r := range grid {
+		current[r] = append([]bool(nil), grid[r]...)
+		round[r] = make([]int, cols)
+	}
+	for {
+		var removed [][2]int
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if !current[r][c] {
+					continue
+				}
+				count := 0
+				for _, off := range offsets {
+					r2, c2 := r+off[0], c+off[1]
+					if r2 >= 0 && r2 < rows && c2 >= 0 && c2 < cols && current[r2][c2] {
+						count++
+					}
+				}
+				if count < threshold {
+					removed = append(removed, [2]int{r, c})
+				}
+			}
+		}
+		if len(removed) == 0 {
+			return perRound, round
+		}
+		perRound = append(perRound, len(removed))
+		for _, rc := range removed {
+			current[rc[0]][rc[1]] = false
+			round[rc[0]][rc[1]] = len(perRound)
+		}
+	}
+}
+
+func TestEngineMatchesNaive(t *testing.T) {
+	knight := Neighborhood{{1, 2}, {2, 1}, {-1, 2}, {-2, 1}, {1, -2}, {2, -1}, {-1, -2}, {-2, -1}}
+	// Only looks down and right, so counts are not symmetric.
+	lopsided := Neighborhood{{0, 1}, {1, 0}, {1, 1}, {0, 2}}
+	// Fits in the surrounding cells, so it is also counted bitwise.
+	diagonal := Neighborhood{{-1, -1}, {0, 1}, {1, 1}}
+	neighborhoods := []Neighborhood{VonNeumann, Moore, knight, lopsided, diagonal}
+
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 600; trial++ {
+		rows, cols := 1+rng.Intn(12), 1+rng.Intn(12)
+		if trial%3 == 0 {
+			// Rows spanning several words.
+			cols = 1 + rng.Intn(200)
+		}
+		density := 1 + rng.Intn(9)
+		grid := make([][]bool, rows)
+		for r := range grid {
+			grid[r] = make([]bool, cols)
+			for c := range grid[r] {
+				grid[r][c] = rng.Intn(10) < density
+			}
+		}
+		hood := neighborhoods[rng.Intn(len(neighborhoods))]
+		threshold := 1 + rng.Intn(len(hood))
+
+		engine := Engine{Neighborhood: hood, Threshold: threshold}
+		packed := packGrid(grid)
+		got := engine.erode(packed)
+		wantPerRound, wantRound := naiveErosion(grid, hood, threshold)
+		if !reflect.DeepEqual(got.PerRound, wantPerRound) {
+			t.Fatalf("%v threshold %d: PerRound = %v, want %v", hood, threshold, got.PerRound, wantPerRound)
+		}
+		if bc, ok := engine.bitCounter(); ok {
+			if perRound := bc.erode(packed); !reflect.DeepEqual(perRound, wantPerRound) {
+				t.Fatalf("%v threshold %d: bitCounter PerRound = %v, want %v", hood, threshold, perRound, wantPerRound)
+			}
+		}
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if got.RemovedIn(r, c) != wantRound[r][c] || got.Occupied(r, c) != grid[r][c] {
+					t.Fatalf("%v threshold %d: cell %d,%d removed in %d, want %d", hood, threshold, r, c, got.RemovedIn(r, c), wantRound[r][c])
+				}
+			}
+		}
+	}
+}
+
+func TestEngineErode(t *testing.T) {
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	erosion, err := Engine{}.Erode(strings.NewReader(string(sample)))
+	if err != nil {
+		t.Fatalf("Erode() error = %v", err)
+	}
+	if want := []int{13, 12, 7, 5, 2, 1, 1, 1, 1}; !reflect.DeepEqual(erosion.PerRound, want) || erosion.Total() != 43 {
+		t.Fatalf("PerRound = %v (total %d), want %v (total 43)", erosion.PerRound, erosion.Total(), want)
+	}
+
+	// With the four orthogonal neighbours and a threshold of 2, only rolls
+	// that touch at most one other roll go.
+	erosion, err = Engine{Neighborhood: VonNeumann, Threshold: 2}.Erode(strings.NewReader("@@@\n.@.\n@.@\n"))
+	if err != nil {
+		t.Fatalf("Erode() error = %v", err)
+	}
+	if want := []int{5, 1}; !reflect.DeepEqual(erosion.PerRound, want) {
+		t.Fatalf("PerRound = %v, want %v", erosion.PerRound, want)
+	}
+}
+
+func smallErosion(t *testing.T) Erosion {
+	t.Helper()
+	erosion, err := Engine{Neighborhood: VonNeumann, Threshold: 2}.Erode(strings.NewReader("@@@\n.@.\n@.@\n"))
+	if err != nil {
+		t.Fatalf("Erode() error = %v", err)
+	}
+	return erosion
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := smallErosion(t).WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	want := `ROUND 0 REMOVED 0
+@@@
+.@.
+@.@
+
+ROUND 1 REMOVED 5
+1@1
+.1.
+1.1
+
+ROUND 2 REMOVED 1
+121
+.1.
+1.1
+`
+	if buf.String() != want {
+		t.Fatalf("WriteText() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteANSI(t *testing.T) {
+	var buf bytes.Buffer
+	if err := smallErosion(t).WriteANSI(&buf, 0); err != nil {
+		t.Fatalf("WriteANSI() error = %v", err)
+	}
+	out := buf.String()
+	if got := strings.Count(out, "\x1b[2J"); got != 3 {
+		t.Errorf("WriteANSI() cleared the screen %d times, want 3", got)
+	}
+	if !strings.Contains(out, "ROUND 2 REMOVED 1\n") {
+		t.Errorf("WriteANSI() is missing the last frame's label")
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	erosion := smallErosion(t)
+	var buf bytes.Buffer
+	if err := erosion.WriteGIF(&buf, 4, 50*time.Millisecond); err != nil {
+		t.Fatalf("WriteGIF() error = %v", err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("DecodeAll() error = %v", err)
+	}
+	if len(anim.Image) != 3 || anim.Delay[0] != 5 || anim.Delay[2] != 100 {
+		t.Fatalf("got %d frames with delays %v, want 3 with 5, 5, 100", len(anim.Image), anim.Delay)
+	}
+
+	// Sample the middle of cell (0, 1), which survives round 1 and goes in
+	// round 2, and of cell (0, 0), which goes in round 1.
+	last := anim.Image[2]
+	y := last.Bounds().Dy() - 3*4 + 2
+	if got, want := last.At(6, y), erosion.roundColor(2); got != want {
+		t.Errorf("cell (0, 1) in the last frame = %v, want %v", got, want)
+	}
+	if got, want := anim.Image[1].At(6, y), occupiedColor; got != want {
+		t.Errorf("cell (0, 1) in frame 1 = %v, want %v", got, want)
+	}
+	if got, want := last.At(2, y), erosion.roundColor(1); got != want {
+		t.Errorf("cell (0, 0) in the last frame = %v, want %v", got, want)
+	}
+}
+
+func TestParseNeighborhood(t *testing.T) {
+	for _, good := range []string{"4", "8", "1,2; 2,1", "-1,0"} {
+		if _, err := ParseNeighborhood(good); err != nil {
+			t.Errorf("ParseNeighborhood(%q) error = %v", good, err)
+		}
+	}
+	for _, bad := range []string{"", "6", "0,0", "1,1;1,1", "1;2", "a,b"} {
+		if _, err := ParseNeighborhood(bad); err == nil {
+			t.Errorf("ParseNeighborhood(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
+
+// BenchmarkErode compares the ways of eroding a large floor: rescanning
+// every cell each round, the per-cell worklist and the packed rows.
+func BenchmarkErode(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	grid := make([][]bool, 1000)
+	for r := range grid {
+		grid[r] = make([]bool, 1000)
+		for c := range grid[r] {
+			grid[r][c] = rng.Intn(3) != 0
+		}
+	}
+	packed := packGrid(grid)
+	bc, _ := Engine{}.bitCounter()
+
+	b.Run("rescan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			naiveErosion(grid, Moore, 4)
+		}
+	})
+	b.Run("worklist", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Engine{}.erode(packed)
+		}
+	})
+	b.Run("bitset", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bc.erode(packed)
+		}
+	})
+	b.Run("bitset first round", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bc.firstRound(packed)
+		}
+	})
+}
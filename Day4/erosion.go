@@ -0,0 +1,262 @@
+package day4
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Neighborhood lists the (row, column) offsets at which an occupied cell
+// counts as a neighbour.
+type Neighborhood [][2]int
+
+var (
+	// VonNeumann is the four orthogonal neighbours.
+	VonNeumann = Neighborhood{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	// Moore is the eight surrounding cells, the puzzle's neighbourhood.
+	Moore = Neighborhood{
+		{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+		{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+	}
+)
+
+// ParseNeighborhood reads "4" (VonNeumann), "8" (Moore) or custom offsets
+// written as "dr,dc;dr,dc;...".
+func ParseNeighborhood(s string) (Neighborhood, error) {
+	switch s {
+	case "4":
+		return VonNeumann, nil
+	case "8":
+		return Moore, nil
+	}
+	var n Neighborhood
+	for _, pair := range strings.Split(s, ";") {
+		dr, dc, ok := strings.Cut(strings.TrimSpace(pair), ",")
+		r, errR := strconv.Atoi(strings.TrimSpace(dr))
+		c, errC := strconv.Atoi(strings.TrimSpace(dc))
+		if !ok || errR != nil || errC != nil {
+			return nil, fmt.Errorf("invalid offset %q (want dr,dc)", pair)
+		}
+		n = append(n, [2]int{r, c})
+	}
+	return n, n.Validate()
+}
+
+// Validate reports whether n is a usable neighbourhood: non-empty, without
+// the cell itself and without repeated offsets.
+func (n Neighborhood) Validate() error {
+	if len(n) == 0 {
+		return fmt.Errorf("empty neighbourhood")
+	}
+	seen := make(map[[2]int]bool, len(n))
+	for _, off := range n {
+		if off == [2]int{0, 0} {
+			return fmt.Errorf("neighbourhood contains the cell itself")
+		}
+		if seen[off] {
+			return fmt.Errorf("offset %d,%d repeated", off[0], off[1])
+		}
+		seen[off] = true
+	}
+	return nil
+}
+
+// Engine erodes a grid in rounds: every round removes, all at once, each
+// occupied cell with fewer than Threshold occupied neighbours. A nil
+// Neighborhood means Moore and a zero Threshold means 4, the puzzle's rule.
+type Engine struct {
+	Neighborhood Neighborhood
+	Threshold    int
+}
+
+func (e Engine) neighborhood() Neighborhood {
+	if e.Neighborhood == nil {
+		return Moore
+	}
+	return e.Neighborhood
+}
+
+func (e Engine) threshold() int {
+	if e.Threshold == 0 {
+		return 4
+	}
+	return e.Threshold
+}
+
+// Validate reports whether e has a usable neighbourhood and threshold.
+func (e Engine) Validate() error {
+	if e.Threshold < 0 {
+		return fmt.Errorf("negative threshold %d", e.Threshold)
+	}
+	return e.neighborhood().Validate()
+}
+
+// Erode reads a grid from r and erodes it to a standstill.
+func (e Engine) Erode(r io.Reader) (Erosion, error) {
+	if err := e.Validate(); err != nil {
+		return Erosion{}, err
+	}
+	grid, err := readGrid(r)
+	if err != nil {
+		return Erosion{}, err
+	}
+	return e.erode(grid), nil
+}
+
+// Erosion records which round removed each cell of a grid.
+type Erosion struct {
+	Rows, Cols int
+	// PerRound[i] is the number of cells round i+1 removed. Erosion stops
+	// after the first round that would remove nothing, which is not listed.
+	PerRound []int
+	occupied []bool
+	// round is the round that removed each cell, row by row, or 0.
+	round []int
+}
+
+// Total returns the number of cells removed over all rounds.
+func (e Erosion) Total() int {
+	total := 0
+	for _, n := range e.PerRound {
+		total += n
+	}
+	return total
+}
+
+// Occupied reports whether cell (r, c) was occupied before the first round.
+func (e Erosion) Occupied(r, c int) bool {
+	return e.occupied[r*e.Cols+c]
+}
+
+// RemovedIn returns the round, counting from 1, that removed cell (r, c),
+// or 0 if the cell was empty or survived.
+func (e Erosion) RemovedIn(r, c int) int {
+	return e.round[r*e.Cols+c]
+}
+
+// eroder keeps every cell's count of occupied neighbours, so a round only
+// has to look at the cells whose counts the previous round lowered.
+type eroder struct {
+	rows, cols int
+	threshold  int
+	offsets    Neighborhood
+	occupied   []bool
+	counts     []int
+}
+
+func (e Engine) newEroder(grid *bitGrid) *eroder {
+	er := &eroder{
+		rows:      grid.rows,
+		cols:      grid.cols,
+		threshold: e.threshold(),
+		offsets:   e.neighborhood(),
+	}
+	er.occupied = make([]bool, er.rows*er.cols)
+	er.counts = make([]int, er.rows*er.cols)
+	for r := 0; r < er.rows; r++ {
+		for c := 0; c < er.cols; c++ {
+			er.occupied[r*er.cols+c] = grid.get(r, c)
+		}
+	}
+	for r := 0; r < er.rows; r++ {
+		for c := 0; c < er.cols; c++ {
+			for _, off := range er.offsets {
+				if i, ok := er.index(r+off[0], c+off[1]); ok && er.occupied[i] {
+					er.counts[r*er.cols+c]++
+				}
+			}
+		}
+	}
+	return er
+}
+
+func (er *eroder) index(r, c int) (int, bool) {
+	if r < 0 || r >= er.rows || c < 0 || c >= er.cols {
+		return 0, false
+	}
+	return r*er.cols + c, true
+}
+
+// firstRound returns the cells the first round removes.
+func (er *eroder) firstRound() []int {
+	var cells []int
+	for i, occ := range er.occupied {
+		if occ && er.counts[i] < er.threshold {
+			cells = append(cells, i)
+		}
+	}
+	return cells
+}
+
+// remove empties cells and returns the occupied cells that dropped below
+// the threshold as a result, which the next round removes. queued marks
+// cells already returned so each is listed once.
+func (er *eroder) remove(cells []int, queued []bool) []int {
+	for _, i := range cells {
+		er.occupied[i] = false
+	}
+	var next []int
+	for _, i := range cells {
+		r, c := i/er.cols, i%er.cols
+		// The cells that count i as a neighbour sit at i minus each offset.
+		for _, off := range er.offsets {
+			j, ok := er.index(r-off[0], c-off[1])
+			if !ok {
+				continue
+			}
+			er.counts[j]--
+			if er.occupied[j] && !queued[j] && er.counts[j] < er.threshold {
+				queued[j] = true
+				next = append(next, j)
+			}
+		}
+	}
+	return next
+}
+
+// accessible counts the cells the first round removes.
+func (e Engine) accessible(grid *bitGrid) int {
+	if bc, ok := e.bitCounter(); ok {
+		return bc.firstRound(grid)
+	}
+	return len(e.newEroder(grid).firstRound())
+}
+
+// removable counts the cells erosion removes over all rounds. Unlike
+// erode it does not record which round removed each cell, so it can count
+// a word of cells at a time when the neighbourhood allows.
+func (e Engine) removable(grid *bitGrid) int {
+	if bc, ok := e.bitCounter(); ok {
+		total := 0
+		for _, n := range bc.erode(grid) {
+			total += n
+		}
+		return total
+	}
+	return e.erode(grid).Total()
+}
+
+func (e Engine) erode(grid *bitGrid) Erosion {
+	er := e.newEroder(grid)
+	result := Erosion{
+		Rows:     er.rows,
+		Cols:     er.cols,
+		occupied: append([]bool(nil), er.occupied...),
+		round:    make([]int, len(er.occupied)),
+	}
+
+	queued := make([]bool, len(er.occupied))
+	cells := er.firstRound()
+	for _, i := range cells {
+		queued[i] = true
+	}
+	for len(cells) > 0 {
+		result.PerRound = append(result.PerRound, len(cells))
+		for _, i := range cells {
+			result.round[i] = len(result.PerRound)
+		}
+		cells = er.remove(cells, queued)
+	}
+	return result
+}
@@ -0,0 +1,289 @@
+package day4
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"math"
+	"time"
+)
+
+// Frame is the grid after some number of rounds of erosion; frame 0 is the
+// grid before the first round.
+type Frame struct {
+	Round int
+	// Removed is the number of cells this frame's round removed.
+	Removed int
+	// Remaining is the number of occupied cells left.
+	Remaining int
+}
+
+// Label is the frame's caption, as the exporters print it.
+func (f Frame) Label() string {
+	return fmt.Sprintf("ROUND %d REMOVED %d", f.Round, f.Removed)
+}
+
+// Frames lists the frames of e, one per round after the initial grid.
+func (e Erosion) Frames() []Frame {
+	remaining := 0
+	for _, occ := range e.occupied {
+		if occ {
+			remaining++
+		}
+	}
+	frames := []Frame{{Remaining: remaining}}
+	for i, n := range e.PerRound {
+		remaining -= n
+		frames = append(frames, Frame{Round: i + 1, Removed: n, Remaining: remaining})
+	}
+	return frames
+}
+
+// state returns what cell i looks like in frame f: 0 for empty, -1 for
+// occupied, or the round that removed it.
+func (e Erosion) state(f Frame, i int) int {
+	switch {
+	case !e.occupied[i]:
+		return 0
+	case e.round[i] == 0 || e.round[i] > f.Round:
+		return -1
+	}
+	return e.round[i]
+}
+
+// roundColor spreads the rounds over a red to blue ramp, so early removals
+// are warm and late ones cool.
+func (e Erosion) roundColor(round int) color.RGBA {
+	t := 0.0
+	if len(e.PerRound) > 1 {
+		t = float64(round-1) / float64(len(e.PerRound)-1)
+	}
+	return hue(240 * t)
+}
+
+// hue returns the fully saturated colour at h degrees.
+func hue(h float64) color.RGBA {
+	x := 1 - math.Abs(math.Mod(h/60, 2)-1)
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g = 1, x
+	case h < 120:
+		r, g = x, 1
+	case h < 180:
+		g, b = 1, x
+	default:
+		g, b = x, 1
+	}
+	return color.RGBA{uint8(255 * r), uint8(255 * g), uint8(255 * b), 255}
+}
+
+var (
+	emptyColor    = color.RGBA{0, 0, 0, 255}
+	occupiedColor = color.RGBA{230, 230, 230, 255}
+	labelColor    = color.RGBA{255, 255, 255, 255}
+)
+
+// roundMark is the character a text snapshot shows for a cell removed in
+// the given round: 1-9, then a-z, then + for anything later.
+func roundMark(round int) byte {
+	switch {
+	case round < 10:
+		return byte('0' + round)
+	case round < 36:
+		return byte('a' + round - 10)
+	}
+	return '+'
+}
+
+// WriteText writes a snapshot of every frame to w: its label, then the grid
+// with @ for occupied cells, . for empty ones and the round mark (1-9, a-z,
+// +) of removed ones. Snapshots are separated by blank lines.
+func (e Erosion) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	line := make([]byte, e.Cols)
+	for n, f := range e.Frames() {
+		if n > 0 {
+			bw.WriteByte('\n')
+		}
+		fmt.Fprintln(bw, f.Label())
+		for r := 0; r < e.Rows; r++ {
+			for c := range line {
+				switch s := e.state(f, r*e.Cols+c); s {
+				case 0:
+					line[c] = '.'
+				case -1:
+					line[c] = '@'
+				default:
+					line[c] = roundMark(s)
+				}
+			}
+			bw.Write(line)
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteANSI plays the frames on a terminal, redrawing the screen for each
+// one and pausing delay between them. Each cell is two columns wide and
+// coloured with a 24-bit background.
+func (e Erosion) WriteANSI(w io.Writer, delay time.Duration) error {
+	bw := bufio.NewWriter(w)
+	for n, f := range e.Frames() {
+		if n > 0 && delay > 0 {
+			if err := bw.Flush(); err != nil {
+				return err
+			}
+			time.Sleep(delay)
+		}
+		fmt.Fprintf(bw, "\x1b[H\x1b[2J%s\n", f.Label())
+		for r := 0; r < e.Rows; r++ {
+			prev := color.RGBA{}
+			for c := 0; c < e.Cols; c++ {
+				col := e.cellColor(f, r*e.Cols+c)
+				if c == 0 || col != prev {
+					fmt.Fprintf(bw, "\x1b[48;2;%d;%d;%dm", col.R, col.G, col.B)
+					prev = col
+				}
+				bw.WriteString("  ")
+			}
+			bw.WriteString("\x1b[0m\n")
+		}
+	}
+	return bw.Flush()
+}
+
+func (e Erosion) cellColor(f Frame, i int) color.RGBA {
+	switch s := e.state(f, i); s {
+	case 0:
+		return emptyColor
+	case -1:
+		return occupiedColor
+	default:
+		return e.roundColor(s)
+	}
+}
+
+// WriteGIF encodes the frames as an animated GIF, drawing each cell as a
+// cellSize-pixel square under the frame's label and showing each frame for
+// delay. The last frame is held for a second before the animation loops.
+func (e Erosion) WriteGIF(w io.Writer, cellSize int, delay time.Duration) error {
+	if cellSize <= 0 {
+		return fmt.Errorf("invalid cell size %d", cellSize)
+	}
+	palette := color.Palette{emptyColor, occupiedColor, labelColor}
+	// The remaining entries are the rounds, or a sample of them if there
+	// are more rounds than a GIF palette holds.
+	shades := min(len(e.PerRound), 256-len(palette))
+	base := len(palette)
+	for i := 0; i < shades; i++ {
+		round := 1
+		if shades > 1 {
+			round = 1 + i*(len(e.PerRound)-1)/(shades-1)
+		}
+		palette = append(palette, e.roundColor(round))
+	}
+	shade := func(round int) uint8 {
+		if shades <= 1 {
+			return uint8(base)
+		}
+		return uint8(base + (round-1)*(shades-1)/(len(e.PerRound)-1))
+	}
+
+	frames := e.Frames()
+	pixel := max(1, cellSize/2)
+	labelHeight := (glyphHeight + 2) * pixel
+	width := e.Cols * cellSize
+	for _, f := range frames {
+		width = max(width, (len(f.Label())*(glyphWidth+1)+1)*pixel)
+	}
+	bounds := image.Rect(0, 0, width, labelHeight+e.Rows*cellSize)
+
+	anim := &gif.GIF{}
+	for n, f := range frames {
+		img := image.NewPaletted(bounds, palette)
+		drawLabel(img, f.Label(), pixel, 2)
+		for r := 0; r < e.Rows; r++ {
+			for c := 0; c < e.Cols; c++ {
+				var index uint8
+				switch s := e.state(f, r*e.Cols+c); s {
+				case 0:
+					continue
+				case -1:
+					index = 1
+				default:
+					index = shade(s)
+				}
+				y0, x0 := labelHeight+r*cellSize, c*cellSize
+				for y := y0; y < y0+cellSize; y++ {
+					row := img.Pix[y*img.Stride+x0 : y*img.Stride+x0+cellSize]
+					for x := range row {
+						row[x] = index
+					}
+				}
+			}
+		}
+		hold := int(delay / (10 * time.Millisecond))
+		if n == len(frames)-1 {
+			hold = max(hold, 100)
+		}
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, hold)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+const glyphWidth, glyphHeight = 3, 5
+
+// glyphs is a 3×5 font covering the characters of Frame.Label, one row of
+// three bits per string.
+var glyphs = map[rune][glyphHeight]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", ".##", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'D': {"##.", "#.#", "#.#", "#.#", "##."},
+	'E': {"###", "#..", "##.", "#..", "###"},
+	'M': {"#.#", "###", "###", "#.#", "#.#"},
+	'N': {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'O': {"###", "#.#", "#.#", "#.#", "###"},
+	'R': {"##.", "#.#", "##.", "#.#", "#.#"},
+	'U': {"#.#", "#.#", "#.#", "#.#", "###"},
+	'V': {"#.#", "#.#", "#.#", "#.#", ".#."},
+}
+
+// drawLabel writes text along the top of img in palette colour index,
+// scaling each font pixel to a pixel-sized square. Characters outside the
+// font are left blank.
+func drawLabel(img *image.Paletted, text string, pixel int, index uint8) {
+	for i, ch := range text {
+		glyph, ok := glyphs[ch]
+		if !ok {
+			continue
+		}
+		for gy, bits := range glyph {
+			for gx := range bits {
+				if bits[gx] != '#' {
+					continue
+				}
+				x0 := (1 + i*(glyphWidth+1) + gx) * pixel
+				y0 := (1 + gy) * pixel
+				for y := y0; y < y0+pixel; y++ {
+					for x := x0; x < x0+pixel; x++ {
+						img.SetColorIndex(x, y, index)
+					}
+				}
+			}
+		}
+	}
+}
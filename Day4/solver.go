@@ -0,0 +1,44 @@
+package day4
+
+import (
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's grid parser and erosion to the aoc.Solver
+// interface. The zero Engine is the puzzle's rule.
+type Solver struct {
+	Engine Engine
+}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 4 }
+
+// Parse implements aoc.Solver.
+func (s Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	if err := s.Engine.Validate(); err != nil {
+		return nil, err
+	}
+	grid, err := readGrid(r)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{grid: grid, engine: s.Engine}, nil
+}
+
+type puzzle struct {
+	grid   *bitGrid
+	engine Engine
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	return aoc.Int(int64(p.engine.accessible(p.grid))), nil
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	return aoc.Int(int64(p.engine.removable(p.grid))), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,24 @@
+package day4
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+// synthetic generates a 100-column floor with scale*10 rows where roughly
+// two thirds of the cells hold a roll of paper.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	var buf bytes.Buffer
+	for r := 0; r < scale*10; r++ {
+		for c := 0; c < 100; c++ {
+			if rng.Intn(3) == 0 {
+				buf.WriteByte('.')
+			} else {
+				buf.WriteByte('@')
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"aoc25/Day5"
+	"aoc25/aoc"
+)
+
+func main() {
+	report := flag.Bool("report", false, "instead of solving, write a CSV line per ID with the ranges containing it and its distance from the nearest fresh ID")
+	stream := flag.Bool("stream", false, "check IDs as they are read instead of loading them all, for ID lists too big for memory")
+	workers := flag.Int("workers", 0, "with -stream, check IDs on `n` goroutines (0 for one per CPU)")
+	flag.Parse()
+	if !*report && !*stream {
+		aoc.MainArgs(day5.Solver{}, flag.Args())
+		return
+	}
+
+	path := aoc.InputPath(5, flag.Args())
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+	if *report {
+		if err := writeReport(os.Stdout, file); err != nil {
+			aoc.Fatal(path, err)
+		}
+		return
+	}
+	fresh, covered, err := day5.SolveStream(file, *workers)
+	if err != nil {
+		aoc.Fatal(path, err)
+	}
+	fmt.Printf("Part 1: %d\n", fresh)
+	fmt.Printf("Part 2: %d\n", covered)
+}
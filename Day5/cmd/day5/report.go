@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"aoc25/Day5"
+)
+
+var reportColumns = []string{"id", "line", "fresh", "ranges", "range_lines", "distance"}
+
+// writeReport writes the freshness of every ID in r as CSV. A fresh ID's
+// containing ranges and their input lines are listed, separated by
+// semicolons; a spoiled ID has none and a positive distance.
+func writeReport(w io.Writer, r io.Reader) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportColumns); err != nil {
+		return err
+	}
+	var writeErr error
+	err := day5.Report(r, func(f day5.Freshness) bool {
+		ranges := make([]string, len(f.Sources))
+		lines := make([]string, len(f.Sources))
+		for i, src := range f.Sources {
+			ranges[i] = src.Range.String()
+			lines[i] = strconv.Itoa(src.Line)
+		}
+		writeErr = cw.Write([]string{
+			strconv.FormatInt(f.ID, 10),
+			strconv.Itoa(f.Line),
+			strconv.FormatBool(f.Fresh()),
+			strings.Join(ranges, ";"),
+			strings.Join(lines, ";"),
+			strconv.FormatInt(f.Distance, 10),
+		})
+		return writeErr == nil
+	})
+	if err == nil {
+		err = writeErr
+	}
+	cw.Flush()
+	if err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -0,0 +1,145 @@
+// Package day5 solves Advent of Code 2025 day 5: Cafeteria.
+package day5
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"aoc25/aoc"
+)
+
+// SourceRange is a fresh range as the input wrote it, before merging.
+type SourceRange struct {
+	Range
+	// Line is the input line of the range.
+	Line int
+}
+
+func (s SourceRange) String() string {
+	return fmt.Sprintf("%v (line %d)", s.Range, s.Line)
+}
+
+// ingredient is a queried ID and its input line.
+type ingredient struct {
+	id   int64
+	line int
+}
+
+func Solve(r io.Reader) (int, int64, error) {
+	ranges, ids, err := parseInput(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fresh := newIntervalSet(ranges)
+	countFresh := countFreshIDs(ids, fresh)
+	totalFresh := fresh.Covered()
+
+	return countFresh, totalFresh, nil
+}
+
+func parseInput(r io.Reader) ([]SourceRange, []ingredient, error) {
+	var ranges []SourceRange
+	var ids []ingredient
+	err := scanInput(r, func(rg SourceRange) {
+		ranges = append(ranges, rg)
+	}, func(in ingredient) {
+		ids = append(ids, in)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ranges, ids, nil
+}
+
+// scanInput reads r, passing each range to addRange and then each ID to
+// addID as it is read. Every range comes before the first ID.
+func scanInput(r io.Reader, addRange func(SourceRange), addID func(ingredient)) error {
+	scanner := bufio.NewScanner(r)
+	section := 0
+	lineNumber := 0
+	ranges := 0
+	for scanner.Scan() {
+		lineNumber++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			section++
+			continue
+		}
+		if section == 0 {
+			iv, err := parseInterval(line)
+			if err != nil {
+				return aoc.AtLine(err, lineNumber, aoc.LeadingSpace(raw), raw)
+			}
+			addRange(SourceRange{Range: iv, Line: lineNumber})
+			ranges++
+		} else {
+			id, err := parseInt64(line, 1)
+			if err != nil {
+				return aoc.AtLine(err, lineNumber, aoc.LeadingSpace(raw), raw)
+			}
+			addID(ingredient{id: id, line: lineNumber})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if ranges == 0 {
+		return &aoc.ParseError{Msg: "no ranges provided"}
+	}
+	return nil
+}
+
+// parseInterval parses "start-end". Errors carry the column within line and
+// are placed on their input line by the caller.
+func parseInterval(line string) (Range, error) {
+	parts := strings.Split(line, "-")
+	if len(parts) != 2 {
+		return Range{}, aoc.ParseErrorf(0, 1, "", "invalid range %q", line)
+	}
+	start, err := parseInt64(strings.TrimSpace(parts[0]), 1)
+	if err != nil {
+		return Range{}, err
+	}
+	endCol := len(parts[0]) + 2 + aoc.LeadingSpace(parts[1])
+	end, err := parseInt64(strings.TrimSpace(parts[1]), endCol)
+	if err != nil {
+		return Range{}, err
+	}
+	if start > end {
+		start, end = end, start
+	}
+	return Range{Start: start, End: end}, nil
+}
+
+// parseInt64 parses s, which starts at column col of its line.
+func parseInt64(s string, col int) (int64, error) {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, aoc.ParseErrorf(0, col, "", "invalid integer %q: %w", s, err)
+	}
+	return value, nil
+}
+
+// newIntervalSet returns the set of IDs in any of ranges.
+func newIntervalSet(ranges []SourceRange) *IntervalSet {
+	set := &IntervalSet{}
+	for _, rg := range ranges {
+		set.Add(rg.Range)
+	}
+	return set
+}
+
+func countFreshIDs(ids []ingredient, fresh *IntervalSet) int {
+	count := 0
+	for _, in := range ids {
+		if fresh.Contains(in.id) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,320 @@
+package day5
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "3", Part2: "14"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "no ranges", Input: "\n1\n2\n", Line: 0, Column: 0},
+		{Name: "range", Input: "3-5\n10-14-16\n", Line: 2, Column: 1},
+		{Name: "end", Input: "3-5\n 10- 1x\n", Line: 2, Column: 6},
+		{Name: "id", Input: "3-5\n\n1\nfive\n", Line: 4, Column: 1},
+	})
+}
+
+// domain is the IDs the random interval-set tests draw from, shifted so
+// some are negative.
+const domain = 64
+
+// runs returns the maximal runs of true in in, offset by lo.
+func runs(in []bool, lo int64) []Range {
+	var out []Range
+	for i := 0; i < len(in); i++ {
+		if !in[i] {
+			continue
+		}
+		j := i
+		for j+1 < len(in) && in[j+1] {
+			j++
+		}
+		out = append(out, Range{lo + int64(i), lo + int64(j)})
+		i = j
+	}
+	return out
+}
+
+func collect(each func(func(Range) bool)) []Range {
+	var out []Range
+	each(func(r Range) bool {
+		out = append(out, r)
+		return true
+	})
+	return out
+}
+
+// checkTreap verifies the order, heap and aggregate invariants under n.
+func checkTreap(t *testing.T, n *node) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	for _, child := range []*node{n.left, n.right} {
+		if child != nil && child.priority > n.priority {
+			t.Fatalf("child %v outranks parent %v", child.Range, n.Range)
+		}
+	}
+	if n.left != nil && n.left.last().End+1 >= n.Start || n.right != nil && n.right.first().Start-1 <= n.End {
+		t.Fatalf("ranges around %v overlap or touch", n.Range)
+	}
+	if n.count != 1+n.left.size()+n.right.size() || n.covered != n.Len()+n.left.ids()+n.right.ids() {
+		t.Fatalf("stale aggregates at %v", n.Range)
+	}
+	checkTreap(t, n.left)
+	checkTreap(t, n.right)
+}
+
+// randomSet applies random adds and removes to an IntervalSet and to a
+// slice of flags over the domain.
+func randomSet(rng *rand.Rand, ops int) (*IntervalSet, []bool) {
+	set := &IntervalSet{}
+	in := make([]bool, domain)
+	for i := 0; i < ops; i++ {
+		a, b := rng.Intn(domain), rng.Intn(domain)
+		if a > b {
+			a, b = b, a
+		}
+		add := rng.Intn(3) != 0
+		rg := Range{int64(a) - domain/2, int64(b) - domain/2}
+		if add {
+			set.Add(rg)
+		} else {
+			set.Remove(rg)
+		}
+		for id := a; id <= b; id++ {
+			in[id] = add
+		}
+	}
+	return set, in
+}
+
+func TestIntervalSetMatchesFlags(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 500; trial++ {
+		set, in := randomSet(rng, 1+rng.Intn(20))
+		checkTreap(t, set.root)
+		want := runs(in, -domain/2)
+		if got := collect(set.Ranges); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Ranges() = %v, want %v", got, want)
+		}
+		var covered int64
+		for id, ok := range in {
+			if ok {
+				covered++
+			}
+			if got := set.Contains(int64(id) - domain/2); got != ok {
+				t.Fatalf("%v Contains(%d) = %v, want %v", want, id-domain/2, got, ok)
+			}
+		}
+		if set.Covered() != covered || set.Len() != len(want) {
+			t.Fatalf("%v Covered(), Len() = %d, %d, want %d, %d", want, set.Covered(), set.Len(), covered, len(want))
+		}
+
+		lo, hi := rng.Intn(domain), rng.Intn(domain)
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		gaps := make([]bool, hi-lo+1)
+		for i := range gaps {
+			gaps[i] = !in[lo+i]
+		}
+		bounds := Range{int64(lo) - domain/2, int64(hi) - domain/2}
+		wantGaps := runs(gaps, bounds.Start)
+		gotGaps := collect(func(yield func(Range) bool) { set.Gaps(bounds, yield) })
+		if !reflect.DeepEqual(gotGaps, wantGaps) {
+			t.Fatalf("%v Gaps(%v) = %v, want %v", want, bounds, gotGaps, wantGaps)
+		}
+	}
+}
+
+func TestIntervalSetAlgebra(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 300; trial++ {
+		a, inA := randomSet(rng, rng.Intn(12))
+		b, inB := randomSet(rng, rng.Intn(12))
+		before := collect(a.Ranges)
+
+		ops := []struct {
+			name string
+			got  *IntervalSet
+			keep func(x, y bool) bool
+		}{
+			{"Union", a.Union(b), func(x, y bool) bool { return x || y }},
+			{"Intersect", a.Intersect(b), func(x, y bool) bool { return x && y }},
+			{"Difference", a.Difference(b), func(x, y bool) bool { return x && !y }},
+		}
+		for _, op := range ops {
+			in := make([]bool, domain)
+			for i := range in {
+				in[i] = op.keep(inA[i], inB[i])
+			}
+			checkTreap(t, op.got.root)
+			if got, want := collect(op.got.Ranges), runs(in, -domain/2); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s of %v and %v = %v, want %v", op.name, before, collect(b.Ranges), got, want)
+			}
+		}
+		if after := collect(a.Ranges); !reflect.DeepEqual(after, before) {
+			t.Fatalf("set operations changed their operand from %v to %v", before, after)
+		}
+	}
+}
+
+func TestIntervalSetLimits(t *testing.T) {
+	var set IntervalSet
+	set.Add(Range{math.MaxInt64 - 1, math.MaxInt64})
+	set.Add(Range{math.MinInt64, math.MinInt64 + 1})
+	set.Add(Range{5, 3})
+	if set.Len() != 2 || set.Covered() != 4 || !set.Contains(math.MinInt64) || !set.Contains(math.MaxInt64) {
+		t.Fatalf("Ranges() = %v, want the two extremes", collect(set.Ranges))
+	}
+	set.Remove(Range{math.MinInt64, math.MinInt64})
+	set.Remove(Range{math.MaxInt64, math.MaxInt64})
+	want := []Range{{math.MinInt64 + 1, math.MinInt64 + 1}, {math.MaxInt64 - 1, math.MaxInt64 - 1}}
+	if got := collect(set.Ranges); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ranges() = %v, want %v", got, want)
+	}
+	gaps := collect(func(yield func(Range) bool) { set.Gaps(Range{math.MaxInt64 - 2, math.MaxInt64}, yield) })
+	if want := []Range{{math.MaxInt64 - 2, math.MaxInt64 - 2}, {math.MaxInt64, math.MaxInt64}}; !reflect.DeepEqual(gaps, want) {
+		t.Fatalf("Gaps() = %v, want %v", gaps, want)
+	}
+}
+
+func TestReportMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 300; trial++ {
+		var input strings.Builder
+		var ranges []SourceRange
+		for i := 0; i < 1+rng.Intn(12); i++ {
+			start := rng.Int63n(200)
+			end := start + rng.Int63n(30)
+			fmt.Fprintf(&input, "%d-%d\n", start, end)
+			ranges = append(ranges, SourceRange{Range: Range{start, end}, Line: i + 1})
+		}
+		input.WriteString("\n")
+		var want []Freshness
+		for i := 0; i < 20; i++ {
+			id := rng.Int63n(260) - 30
+			fmt.Fprintf(&input, "%d\n", id)
+			f := Freshness{ID: id, Line: len(ranges) + 2 + i, Distance: math.MaxInt64}
+			for _, rg := range ranges {
+				switch {
+				case id < rg.Start:
+					f.Distance = min(f.Distance, rg.Start-id)
+				case id > rg.End:
+					f.Distance = min(f.Distance, id-rg.End)
+				default:
+					f.Sources = append(f.Sources, rg)
+					f.Distance = 0
+				}
+			}
+			want = append(want, f)
+		}
+
+		var got []Freshness
+		if err := Report(strings.NewReader(input.String()), func(f Freshness) bool {
+			got = append(got, f)
+			return true
+		}); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Report(%q) = %+v, want %+v", input.String(), got, want)
+		}
+	}
+}
+
+func TestReportSample(t *testing.T) {
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	err = Report(strings.NewReader(string(sample)), func(f Freshness) bool {
+		got = append(got, fmt.Sprintf("%d@%d %v %d", f.ID, f.Line, f.Sources, f.Distance))
+		return len(got) < 3
+	})
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	want := []string{"1@6 [] 2", "5@7 [3-5 (line 1)] 0", "8@8 [] 2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Report() = %q, want %q", got, want)
+	}
+}
+
+func TestSolveStreamMatchesCount(t *testing.T) {
+	inputs := []string{"3-5\n", "3-5\n\n"}
+	for _, scale := range []int{1, 10, 40} {
+		inputs = append(inputs, string(synthetic(scale)))
+	}
+	sample, err := testdata.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs = append(inputs, string(sample))
+
+	for _, input := range inputs {
+		ranges, ids, err := parseInput(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fresh := newIntervalSet(ranges)
+		wantCount, wantCovered := countFreshIDs(ids, fresh), fresh.Covered()
+		for _, workers := range []int{0, 1, 3} {
+			count, covered, err := SolveStream(strings.NewReader(input), workers)
+			if err != nil {
+				t.Fatalf("SolveStream() error = %v", err)
+			}
+			if count != wantCount || covered != wantCovered {
+				t.Fatalf("SolveStream(%d workers) on %d IDs = %d, %d, want %d, %d", workers, len(ids), count, covered, wantCount, wantCovered)
+			}
+		}
+	}
+}
+
+func TestSolveStreamParseErrors(t *testing.T) {
+	ids := strings.Repeat("4\n", 3*streamBatch)
+	for _, input := range []string{"3-5\n\n" + ids + "five\n" + ids, "\n" + ids} {
+		_, _, want := Solve(strings.NewReader(input))
+		_, _, err := SolveStream(strings.NewReader(input), 2)
+		if err == nil || err.Error() != want.Error() {
+			t.Errorf("SolveStream() error = %v, want %v", err, want)
+		}
+	}
+}
+
+func BenchmarkSolveStream(b *testing.B) {
+	input := synthetic(1000)
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				if _, _, err := SolveStream(bytes.NewReader(input), workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
@@ -0,0 +1,347 @@
+package day5
+
+import (
+	"fmt"
+	"math"
+)
+
+// Range is the IDs from Start to End inclusive. A Range with Start > End is
+// empty.
+type Range struct {
+	Start, End int64
+}
+
+// Len returns the number of IDs in r.
+func (r Range) Len() int64 {
+	if r.Start > r.End {
+		return 0
+	}
+	return r.End - r.Start + 1
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// IntervalSet is a set of IDs kept as disjoint, non-adjacent ranges in a
+// treap ordered by start. Since the ranges are disjoint they are ordered by
+// end too, so a treap can be split on either. Add, Remove and Contains take
+// O(log n) expected time for n ranges; the zero IntervalSet is empty and
+// ready to use.
+type IntervalSet struct {
+	root *node
+}
+
+type node struct {
+	Range
+	priority    uint64
+	left, right *node
+	// count and covered are the number of ranges and of IDs in the subtree.
+	count   int
+	covered int64
+}
+
+func newNode(r Range) *node {
+	n := &node{Range: r, priority: mix(uint64(r.Start))}
+	return n.update()
+}
+
+// mix is splitmix64's finaliser. Deriving priorities from starts keeps the
+// tree's shape, and so its performance, the same from run to run.
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+func (n *node) update() *node {
+	n.count = 1 + n.left.size() + n.right.size()
+	n.covered = n.Len() + n.left.ids() + n.right.ids()
+	return n
+}
+
+func (n *node) size() int {
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
+
+func (n *node) ids() int64 {
+	if n == nil {
+		return 0
+	}
+	return n.covered
+}
+
+// merge joins two treaps, every range in l before every range in r.
+func merge(l, r *node) *node {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.priority > r.priority:
+		l.right = merge(l.right, r)
+		return l.update()
+	}
+	r.left = merge(l, r.left)
+	return r.update()
+}
+
+// splitStart splits n into the ranges starting at or before key and the
+// rest.
+func splitStart(n *node, key int64) (*node, *node) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.Start <= key {
+		l, r := splitStart(n.right, key)
+		n.right = l
+		return n.update(), r
+	}
+	l, r := splitStart(n.left, key)
+	n.left = r
+	return l, n.update()
+}
+
+// splitEnd splits n into the ranges ending before key and the rest.
+func splitEnd(n *node, key int64) (*node, *node) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.End < key {
+		l, r := splitEnd(n.right, key)
+		n.right = l
+		return n.update(), r
+	}
+	l, r := splitEnd(n.left, key)
+	n.left = r
+	return l, n.update()
+}
+
+func (n *node) first() *node {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *node) last() *node {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// satAdd and satSub step one ID, stopping at the ends of int64.
+func satAdd(x int64) int64 {
+	if x == math.MaxInt64 {
+		return x
+	}
+	return x + 1
+}
+
+func satSub(x int64) int64 {
+	if x == math.MinInt64 {
+		return x
+	}
+	return x - 1
+}
+
+// Add adds the IDs in r, merging it with the ranges it overlaps or touches.
+// An empty r changes nothing.
+func (s *IntervalSet) Add(r Range) {
+	if r.Start > r.End {
+		return
+	}
+	// mid is the ranges that overlap r or sit right next to it.
+	before, after := splitStart(s.root, satAdd(r.End))
+	before, mid := splitEnd(before, satSub(r.Start))
+	if mid != nil {
+		r.Start = min(r.Start, mid.first().Start)
+		r.End = max(r.End, mid.last().End)
+	}
+	s.root = merge(merge(before, newNode(r)), after)
+}
+
+// Remove removes the IDs in r, trimming or splitting the ranges it overlaps.
+// An empty r changes nothing.
+func (s *IntervalSet) Remove(r Range) {
+	if r.Start > r.End {
+		return
+	}
+	before, after := splitStart(s.root, r.End)
+	before, mid := splitEnd(before, r.Start)
+	if mid != nil {
+		if first := mid.first(); first.Start < r.Start {
+			before = merge(before, newNode(Range{first.Start, r.Start - 1}))
+		}
+		if last := mid.last(); last.End > r.End {
+			after = merge(newNode(Range{r.End + 1, last.End}), after)
+		}
+	}
+	s.root = merge(before, after)
+}
+
+// Contains reports whether id is in s.
+func (s *IntervalSet) Contains(id int64) bool {
+	n := s.root
+	for n != nil {
+		switch {
+		case id < n.Start:
+			n = n.left
+		case id > n.End:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Distance returns how far id is from the nearest ID in s: 0 if s contains
+// it, and false if s is empty. Distances past math.MaxInt64 are reported as
+// math.MaxInt64.
+func (s *IntervalSet) Distance(id int64) (int64, bool) {
+	if s.root == nil {
+		return 0, false
+	}
+	dist := int64(math.MaxInt64)
+	for n := s.root; n != nil; {
+		switch {
+		case id < n.Start:
+			dist = min(dist, gap(n.Start, id))
+			n = n.left
+		case id > n.End:
+			dist = min(dist, gap(id, n.End))
+			n = n.right
+		default:
+			return 0, true
+		}
+	}
+	return dist, true
+}
+
+// gap returns hi-lo for lo <= hi, or math.MaxInt64 if that overflows.
+func gap(hi, lo int64) int64 {
+	if d := uint64(hi) - uint64(lo); d <= math.MaxInt64 {
+		return int64(d)
+	}
+	return math.MaxInt64
+}
+
+// Covered returns the number of IDs in s.
+func (s *IntervalSet) Covered() int64 {
+	return s.root.ids()
+}
+
+// Len returns the number of disjoint ranges s is made of.
+func (s *IntervalSet) Len() int {
+	return s.root.size()
+}
+
+// Ranges passes the ranges of s to yield in order until yield returns
+// false. Adjacent ranges are always merged, so no two touch.
+func (s *IntervalSet) Ranges(yield func(Range) bool) {
+	s.root.from(math.MinInt64, yield)
+}
+
+// from passes the ranges of n ending at or after id to yield in order,
+// reporting whether yield asked for more.
+func (n *node) from(id int64, yield func(Range) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.End >= id {
+		if !n.left.from(id, yield) || !yield(n.Range) {
+			return false
+		}
+	}
+	return n.right.from(id, yield)
+}
+
+// Gaps passes the maximal runs of IDs within bounds that are not in s to
+// yield in order until yield returns false.
+func (s *IntervalSet) Gaps(bounds Range, yield func(Range) bool) {
+	if bounds.Start > bounds.End {
+		return
+	}
+	next := bounds.Start
+	done := false
+	s.root.from(bounds.Start, func(r Range) bool {
+		if r.Start > bounds.End {
+			return false
+		}
+		if r.Start > next && !yield(Range{next, r.Start - 1}) {
+			done = true
+			return false
+		}
+		if r.End >= bounds.End {
+			done = true
+			return false
+		}
+		next = r.End + 1
+		return true
+	})
+	if !done {
+		yield(Range{next, bounds.End})
+	}
+}
+
+// Clone returns a copy of s that can change independently.
+func (s *IntervalSet) Clone() *IntervalSet {
+	return &IntervalSet{root: s.root.clone()}
+}
+
+func (n *node) clone() *node {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.left, c.right = n.left.clone(), n.right.clone()
+	return &c
+}
+
+// Union returns the IDs in s or o.
+func (s *IntervalSet) Union(o *IntervalSet) *IntervalSet {
+	if s.Len() < o.Len() {
+		s, o = o, s
+	}
+	u := s.Clone()
+	o.Ranges(func(r Range) bool {
+		u.Add(r)
+		return true
+	})
+	return u
+}
+
+// Intersect returns the IDs in both s and o.
+func (s *IntervalSet) Intersect(o *IntervalSet) *IntervalSet {
+	if s.Len() < o.Len() {
+		s, o = o, s
+	}
+	in := &IntervalSet{}
+	o.Ranges(func(r Range) bool {
+		s.root.from(r.Start, func(q Range) bool {
+			if q.Start > r.End {
+				return false
+			}
+			in.Add(Range{max(q.Start, r.Start), min(q.End, r.End)})
+			return true
+		})
+		return true
+	})
+	return in
+}
+
+// Difference returns the IDs in s but not in o.
+func (s *IntervalSet) Difference(o *IntervalSet) *IntervalSet {
+	d := s.Clone()
+	o.Ranges(func(r Range) bool {
+		d.Remove(r)
+		return true
+	})
+	return d
+}
@@ -0,0 +1,111 @@
+package day5
+
+import (
+	"io"
+	"sort"
+)
+
+// Freshness is what the report says about one queried ID.
+type Freshness struct {
+	ID int64
+	// Line is the input line of the ID.
+	Line int
+	// Sources are the input ranges containing the ID, in input order.
+	Sources []SourceRange
+	// Distance is how far the ID is from the nearest fresh ID: 0 if it is
+	// fresh.
+	Distance int64
+}
+
+// Fresh reports whether any range contains the ID.
+func (f Freshness) Fresh() bool {
+	return len(f.Sources) > 0
+}
+
+// Report reads the ranges and IDs in r and passes the Freshness of each ID
+// to yield, in input order, until yield returns false.
+func Report(r io.Reader, yield func(Freshness) bool) error {
+	ranges, ids, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	tree := newIntervalTree(ranges)
+	fresh := newIntervalSet(ranges)
+	for _, in := range ids {
+		f := Freshness{ID: in.id, Line: in.line, Sources: tree.stab(in.id)}
+		if !f.Fresh() {
+			// parseInput insists on at least one range.
+			f.Distance, _ = fresh.Distance(in.id)
+		}
+		if !yield(f) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// intervalTree finds the source ranges containing an ID. The ranges are
+// sorted by start and read as an implicit balanced tree: the root of the
+// subtree over ranges[lo:hi] is ranges[(lo+hi)/2], and maxEnd at that index
+// is the largest end in the subtree.
+type intervalTree struct {
+	ranges []SourceRange
+	maxEnd []int64
+}
+
+func newIntervalTree(ranges []SourceRange) *intervalTree {
+	t := &intervalTree{
+		ranges: append([]SourceRange(nil), ranges...),
+		maxEnd: make([]int64, len(ranges)),
+	}
+	sort.Slice(t.ranges, func(i, j int) bool {
+		a, b := t.ranges[i], t.ranges[j]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.Line < b.Line
+	})
+	t.build(0, len(t.ranges))
+	return t
+}
+
+func (t *intervalTree) build(lo, hi int) int64 {
+	mid := (lo + hi) / 2
+	end := t.ranges[mid].End
+	if lo < mid {
+		end = max(end, t.build(lo, mid))
+	}
+	if mid+1 < hi {
+		end = max(end, t.build(mid+1, hi))
+	}
+	t.maxEnd[mid] = end
+	return end
+}
+
+// stab returns the ranges containing id in input order.
+func (t *intervalTree) stab(id int64) []SourceRange {
+	var found []SourceRange
+	t.collect(0, len(t.ranges), id, &found)
+	sort.Slice(found, func(i, j int) bool { return found[i].Line < found[j].Line })
+	return found
+}
+
+// collect appends the ranges in the subtree over ranges[lo:hi] that contain
+// id. A subtree whose ends all fall short of id is skipped, as is the right
+// subtree once the root starts after id.
+func (t *intervalTree) collect(lo, hi int, id int64, found *[]SourceRange) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	if t.maxEnd[mid] < id {
+		return
+	}
+	t.collect(lo, mid, id, found)
+	if rg := t.ranges[mid]; rg.Start <= id {
+		if id <= rg.End {
+			*found = append(*found, rg)
+		}
+		t.collect(mid+1, hi, id, found)
+	}
+}
@@ -0,0 +1,39 @@
+package day5
+
+import (
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's parser and interval checks to the aoc.Solver
+// interface.
+type Solver struct{}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 5 }
+
+// Parse implements aoc.Solver.
+func (Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	ranges, ids, err := parseInput(r)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{fresh: newIntervalSet(ranges), ids: ids}, nil
+}
+
+type puzzle struct {
+	fresh *IntervalSet
+	ids   []ingredient
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	return aoc.Int(int64(countFreshIDs(p.ids, p.fresh))), nil
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	return aoc.Int(p.fresh.Covered()), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,117 @@
+package day5
+
+import (
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// streamBatch is how many IDs a worker checks at a time.
+const streamBatch = 4096
+
+// SolveStream is Solve for ID lists too big to hold in memory. The ranges
+// are read and merged first; the IDs are then checked as they are read, in
+// batches spread over up to workers goroutines (GOMAXPROCS when workers is
+// zero or less). Only a few batches are ever held at once, so memory does
+// not grow with the number of IDs.
+func SolveStream(r io.Reader, workers int) (int, int64, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	fresh := &IntervalSet{}
+	var pool *checkPool
+	var batch []int64
+	err := scanInput(r, func(rg SourceRange) {
+		fresh.Add(rg.Range)
+	}, func(in ingredient) {
+		if pool == nil {
+			// The first ID: every range has been read.
+			pool = newCheckPool(fresh, workers)
+			batch = pool.batch()
+		}
+		batch = append(batch, in.id)
+		if len(batch) == cap(batch) {
+			pool.submit(batch)
+			batch = pool.batch()
+		}
+	})
+	count := 0
+	if pool != nil {
+		pool.submit(batch)
+		count = pool.wait()
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return count, fresh.Covered(), nil
+}
+
+// checkPool counts the fresh IDs in the batches submitted to it. Batches are
+// recycled through free, so submitting blocks once the workers fall behind.
+type checkPool struct {
+	merged  []Range
+	batches chan []int64
+	free    chan []int64
+	wg      sync.WaitGroup
+	count   atomic.Int64
+}
+
+func newCheckPool(fresh *IntervalSet, workers int) *checkPool {
+	p := &checkPool{
+		merged:  make([]Range, 0, fresh.Len()),
+		batches: make(chan []int64, workers),
+		free:    make(chan []int64, 2*workers),
+	}
+	fresh.Ranges(func(rg Range) bool {
+		p.merged = append(p.merged, rg)
+		return true
+	})
+	for i := 0; i < cap(p.free); i++ {
+		p.free <- make([]int64, 0, streamBatch)
+	}
+	p.wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer p.wg.Done()
+			for batch := range p.batches {
+				n := 0
+				for _, id := range batch {
+					if idInIntervals(id, p.merged) {
+						n++
+					}
+				}
+				p.count.Add(int64(n))
+				p.free <- batch[:0]
+			}
+		}()
+	}
+	return p
+}
+
+// batch returns an empty batch, waiting for a worker to finish one if all
+// are in use.
+func (p *checkPool) batch() []int64 {
+	return <-p.free
+}
+
+func (p *checkPool) submit(batch []int64) {
+	p.batches <- batch
+}
+
+// wait waits for the submitted batches and returns their fresh IDs.
+func (p *checkPool) wait() int {
+	close(p.batches)
+	p.wg.Wait()
+	return int(p.count.Load())
+}
+
+// idInIntervals binary searches merged, which is sorted and disjoint, for
+// the range containing id.
+func idInIntervals(id int64, merged []Range) bool {
+	idx := sort.Search(len(merged), func(i int) bool {
+		return merged[i].End >= id
+	})
+	return idx < len(merged) && merged[idx].Start <= id
+}
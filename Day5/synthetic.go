@@ -0,0 +1,24 @@
+package day5
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// synthetic generates scale*20 overlapping fresh ranges followed by
+// scale*100 ingredient IDs drawn from the same span.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	const span = 1_000_000_000_000
+	var buf bytes.Buffer
+	for i := 0; i < scale*20; i++ {
+		start := rng.Int63n(span)
+		fmt.Fprintf(&buf, "%d-%d\n", start, start+rng.Int63n(span/1000))
+	}
+	buf.WriteByte('\n')
+	for i := 0; i < scale*100; i++ {
+		fmt.Fprintf(&buf, "%d\n", rng.Int63n(span))
+	}
+	return buf.Bytes()
+}
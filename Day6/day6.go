@@ -1,52 +1,21 @@
-package main
+// Package day6 solves Advent of Code 2025 day 6: Trash Compactor.
+package day6
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 	"strings"
-)
-
-func main() {
-	path := resolveInputPath(os.Args)
-
-	file, err := os.Open(path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
-		os.Exit(1)
-	}
-	defer file.Close()
-
-	part1, part2, err := Solve(file)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "solve error: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Part 1: %d\n", part1)
-	fmt.Printf("Part 2: %d\n", part2)
-}
 
-func resolveInputPath(args []string) string {
-	if len(args) > 1 {
-		return args[1]
-	}
-	if _, err := os.Stat("Day6/input.txt"); err == nil {
-		return "Day6/input.txt"
-	}
-	return "input.txt"
-}
+	"aoc25/aoc"
+)
 
 func Solve(r io.Reader) (int64, int64, error) {
 	grid, err := readGrid(r)
 	if err != nil {
 		return 0, 0, err
 	}
-	if len(grid) == 0 {
-		return 0, 0, fmt.Errorf("empty grid")
-	}
 
 	part1, err := evaluateLeftToRight(grid)
 	if err != nil {
@@ -59,19 +28,43 @@ func Solve(r io.Reader) (int64, int64, error) {
 	return part1, part2, nil
 }
 
+// readGrid reads the worksheet rows and checks their characters: digits
+// and spaces in the number rows, '+', '*' and spaces in the operator row.
 func readGrid(r io.Reader) ([]string, error) {
 	scanner := bufio.NewScanner(r)
 	var lines []string
+	var lineNumbers []int
+	lineNumber := 0
 	for scanner.Scan() {
+		lineNumber++
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
 		lines = append(lines, line)
+		lineNumbers = append(lineNumbers, lineNumber)
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
+	if len(lines) == 0 {
+		return nil, &aoc.ParseError{Msg: "empty grid"}
+	}
+	last := len(lines) - 1
+	for i, line := range lines {
+		for j := 0; j < len(line); j++ {
+			ch := line[j]
+			switch {
+			case ch == ' ':
+			case i == last && (ch == '+' || ch == '*'):
+			case i < last && ch >= '0' && ch <= '9':
+			case i == last:
+				return nil, aoc.ParseErrorf(lineNumbers[i], j+1, line, "invalid operator %q", ch)
+			default:
+				return nil, aoc.ParseErrorf(lineNumbers[i], j+1, line, "invalid digit %q", ch)
+			}
+		}
+	}
 	return lines, nil
 }
 
@@ -0,0 +1,29 @@
+package day6
+
+import (
+	"embed"
+	"testing"
+
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "4277556", Part2: "3263827"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "empty", Input: "\n", Line: 0, Column: 0},
+		{Name: "digit", Input: "12 3\n\n4x 5\n*  +\n", Line: 3, Column: 2},
+		{Name: "operator", Input: "12 3\n 4 5\n*  -\n", Line: 3, Column: 4},
+	})
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
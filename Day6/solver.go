@@ -0,0 +1,46 @@
+package day6
+
+import (
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's worksheet parser and evaluators to the aoc.Solver
+// interface.
+type Solver struct{}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 6 }
+
+// Parse implements aoc.Solver.
+func (Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	grid, err := readGrid(r)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{grid: grid}, nil
+}
+
+type puzzle struct {
+	grid []string
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	total, err := evaluateLeftToRight(p.grid)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(total), nil
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	total, err := evaluateRightToLeft(p.grid)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(total), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
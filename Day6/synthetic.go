@@ -0,0 +1,58 @@
+package day6
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// synthetic generates a worksheet of scale*10 problems, each with three
+// operands of up to four digits aligned left or right within its columns.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	const operands = 3
+	rows := make([]strings.Builder, operands+1)
+	for p := 0; p < scale*10; p++ {
+		width := 1 + rng.Intn(4)
+		values := make([]string, operands)
+		for i := range values {
+			digits := width
+			if i > 0 {
+				digits = 1 + rng.Intn(width)
+			}
+			values[i] = strconv.Itoa(pow10(digits-1) + rng.Intn(9*pow10(digits-1)))
+		}
+		if p > 0 {
+			for i := range rows {
+				rows[i].WriteByte(' ')
+			}
+		}
+		for i, v := range values {
+			pad := strings.Repeat(" ", width-len(v))
+			if rng.Intn(2) == 0 {
+				rows[i].WriteString(v + pad)
+			} else {
+				rows[i].WriteString(pad + v)
+			}
+		}
+		op := "+"
+		if rng.Intn(2) == 0 {
+			op = "*"
+		}
+		rows[operands].WriteString(op + strings.Repeat(" ", width-1))
+	}
+	var out strings.Builder
+	for i := range rows {
+		out.WriteString(rows[i].String())
+		out.WriteByte('\n')
+	}
+	return []byte(out.String())
+}
+
+func pow10(n int) int {
+	v := 1
+	for i := 0; i < n; i++ {
+		v *= 10
+	}
+	return v
+}
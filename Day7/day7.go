@@ -1,51 +1,20 @@
-package main
+// Package day7 solves Advent of Code 2025 day 7: Laboratories.
+package day7
 
 import (
 	"bufio"
 	"fmt"
 	"io"
 	"math/big"
-	"os"
-)
-
-func main() {
-	path := resolveInputPath(os.Args)
-
-	file, err := os.Open(path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
-		os.Exit(1)
-	}
-	defer file.Close()
-
-	part1, part2, err := Solve(file)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "solve error: %v\n", err)
-		os.Exit(1)
-	}
 
-	fmt.Printf("Part 1: %d\n", part1)
-	fmt.Printf("Part 2: %s\n", part2.String())
-}
-
-func resolveInputPath(args []string) string {
-	if len(args) > 1 {
-		return args[1]
-	}
-	if _, err := os.Stat("Day7/input.txt"); err == nil {
-		return "Day7/input.txt"
-	}
-	return "input.txt"
-}
+	"aoc25/aoc"
+)
 
 func Solve(r io.Reader) (int64, *big.Int, error) {
 	grid, err := readGrid(r)
 	if err != nil {
 		return 0, nil, err
 	}
-	if len(grid) == 0 {
-		return 0, nil, fmt.Errorf("empty grid")
-	}
 	startRow, startCol, err := findStart(grid)
 	if err != nil {
 		return 0, nil, err
@@ -75,6 +44,9 @@ func readGrid(r io.Reader) ([]string, error) {
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
+	if len(lines) == 0 {
+		return nil, &aoc.ParseError{Msg: "empty grid"}
+	}
 	return lines, nil
 }
 
@@ -86,7 +58,7 @@ func findStart(grid []string) (int, int, error) {
 			}
 		}
 	}
-	return 0, 0, fmt.Errorf("no start position found")
+	return 0, 0, &aoc.ParseError{Msg: "no start position found"}
 }
 
 func simulatePart1(grid []string, startRow, startCol int) (int64, error) {
@@ -0,0 +1,52 @@
+package day7
+
+import (
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's grid parser and beam simulations to the aoc.Solver
+// interface.
+type Solver struct{}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 7 }
+
+// Parse implements aoc.Solver.
+func (Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	grid, err := readGrid(r)
+	if err != nil {
+		return nil, err
+	}
+	startRow, startCol, err := findStart(grid)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{grid: grid, startRow: startRow, startCol: startCol}, nil
+}
+
+type puzzle struct {
+	grid     []string
+	startRow int
+	startCol int
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	splits, err := simulatePart1(p.grid, p.startRow, p.startCol)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(splits), nil
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	timelines, err := simulatePart2(p.grid, p.startRow, p.startCol)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.BigInt(timelines), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
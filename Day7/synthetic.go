@@ -0,0 +1,33 @@
+package day7
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+// synthetic generates a 101-column manifold with scale*10 splitter rows; the
+// beam enters at the centre of the top row.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	const width = 101
+	var buf bytes.Buffer
+	row := bytes.Repeat([]byte{'.'}, width)
+	row[width/2] = 'S'
+	buf.Write(row)
+	buf.WriteByte('\n')
+	for r := 0; r < scale*10; r++ {
+		for c := range row {
+			row[c] = '.'
+		}
+		buf.Write(row)
+		buf.WriteByte('\n')
+		for c := 1; c < width-1; c++ {
+			if rng.Intn(4) == 0 {
+				row[c] = '^'
+			}
+		}
+		buf.Write(row)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"aoc25/Day8"
+	"aoc25/aoc"
+)
+
+func main() {
+	aoc.Main(day8.Solver{})
+}
@@ -1,47 +1,22 @@
-package main
+// Package day8 solves Advent of Code 2025 day 8: Playground.
+package day8
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
-)
-
-func main() {
-	path := resolveInputPath(os.Args)
-
-	file, err := os.Open(path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
-		os.Exit(1)
-	}
-	defer file.Close()
 
-	part1, part2, err := Solve(file)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "solve error: %v\n", err)
-		os.Exit(1)
-	}
+	"aoc25/aoc"
+)
 
-	fmt.Printf("Part 1: %d\n", part1)
-	fmt.Printf("Part 2: %d\n", part2)
-}
-
-func resolveInputPath(args []string) string {
-	if len(args) > 1 {
-		return args[1]
-	}
-	if _, err := os.Stat("Day8/input.txt"); err == nil {
-		return "Day8/input.txt"
-	}
-	return "input.txt"
-}
+// defaultLimit is the number of connections the puzzle asks for in part 1.
+const defaultLimit = 1000
 
 func Solve(r io.Reader) (int64, int64, error) {
-	return SolveWithLimit(r, 1000)
+	return SolveWithLimit(r, defaultLimit)
 }
 
 func SolveWithLimit(r io.Reader, limit int) (int64, int64, error) {
@@ -49,15 +24,19 @@ func SolveWithLimit(r io.Reader, limit int) (int64, int64, error) {
 	if err != nil {
 		return 0, 0, err
 	}
-	if len(points) == 0 {
-		return 0, 0, fmt.Errorf("no junction boxes found")
-	}
 
-	pairs := allPairs(points)
-	if len(pairs) == 0 {
-		return 0, 0, fmt.Errorf("need at least two junction boxes")
+	pairs := sortedPairs(points)
+	part1 := circuitProduct(len(points), pairs, limit)
+	part2, err := finalConnection(points, pairs)
+	if err != nil {
+		return 0, 0, err
 	}
+	return part1, part2, nil
+}
 
+// sortedPairs lists every pair of junction boxes, closest first.
+func sortedPairs(points []point) []pair {
+	pairs := allPairs(points)
 	sort.Slice(pairs, func(i, j int) bool {
 		if pairs[i].dist == pairs[j].dist {
 			if pairs[i].i == pairs[j].i {
@@ -67,50 +46,29 @@ func SolveWithLimit(r io.Reader, limit int) (int64, int64, error) {
 		}
 		return pairs[i].dist < pairs[j].dist
 	})
+	return pairs
+}
 
-	uf := newUnionFind(len(points))
-	var part1 int64
-	part1Computed := false
-	var part2 int64
-	part2Computed := false
-	connectionsProcessed := 0
-
-	for _, p := range pairs {
-		if connectionsProcessed < limit {
-			connectionsProcessed++
-		}
-		merged := uf.union(p.i, p.j)
-
-		if !part1Computed && connectionsProcessed == limit {
-			part1 = productOfTopThree(uf)
-			part1Computed = true
-			if part2Computed {
-				break
-			}
-		}
-
-		if merged && !part2Computed && uf.components == 1 {
-			part2 = points[p.i].x * points[p.j].x
-			part2Computed = true
-			if part1Computed {
-				break
-			}
-		}
-	}
-
-	if !part1Computed {
-		part1 = productOfTopThree(uf)
-		part1Computed = true
+// circuitProduct makes the first limit connections and multiplies the sizes
+// of the three largest circuits.
+func circuitProduct(n int, pairs []pair, limit int) int64 {
+	uf := newUnionFind(n)
+	for k := 0; k < limit && k < len(pairs); k++ {
+		uf.union(pairs[k].i, pairs[k].j)
 	}
+	return productOfTopThree(uf)
+}
 
-	if !part2Computed {
-		if uf.components != 1 {
-			return 0, 0, fmt.Errorf("unable to connect all junction boxes")
+// finalConnection keeps connecting until a single circuit remains and
+// multiplies the X coordinates of the pair that closed it.
+func finalConnection(points []point, pairs []pair) (int64, error) {
+	uf := newUnionFind(len(points))
+	for _, p := range pairs {
+		if uf.union(p.i, p.j) && uf.components == 1 {
+			return points[p.i].x * points[p.j].x, nil
 		}
-		return part1, 0, fmt.Errorf("missing final connection information")
 	}
-
-	return part1, part2, nil
+	return 0, fmt.Errorf("unable to connect all junction boxes")
 }
 
 type point struct {
@@ -128,32 +86,36 @@ type pair struct {
 func parsePoints(r io.Reader) ([]point, error) {
 	scanner := bufio.NewScanner(r)
 	var points []point
+	lineNumber := 0
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
+		lineNumber++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
-		coords := strings.Split(line, ",")
+		coords, cols := aoc.SplitColumns(line, ",")
 		if len(coords) != 3 {
-			return nil, fmt.Errorf("invalid coordinate line %q", line)
-		}
-		x, err := strconv.ParseInt(strings.TrimSpace(coords[0]), 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("parse X from %q: %w", line, err)
+			return nil, aoc.ParseErrorf(lineNumber, cols[0], line, "invalid coordinate line %q", strings.TrimSpace(line))
 		}
-		y, err := strconv.ParseInt(strings.TrimSpace(coords[1]), 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("parse Y from %q: %w", line, err)
-		}
-		z, err := strconv.ParseInt(strings.TrimSpace(coords[2]), 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("parse Z from %q: %w", line, err)
+		var xyz [3]int64
+		for i, name := range []string{"X", "Y", "Z"} {
+			v, err := strconv.ParseInt(coords[i], 10, 64)
+			if err != nil {
+				return nil, aoc.ParseErrorf(lineNumber, cols[i], line, "parse %s: %w", name, err)
+			}
+			xyz[i] = v
 		}
-		points = append(points, point{x: x, y: y, z: z})
+		points = append(points, point{x: xyz[0], y: xyz[1], z: xyz[2]})
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
+	if len(points) == 0 {
+		return nil, &aoc.ParseError{Msg: "no junction boxes found"}
+	}
+	if len(points) < 2 {
+		return nil, &aoc.ParseError{Msg: "need at least two junction boxes"}
+	}
 	return points, nil
 }
 
@@ -0,0 +1,29 @@
+package day8
+
+import (
+	"embed"
+	"testing"
+
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{Limit: 10}, []aoctest.Case{
+		{File: "sample.txt", Part1: "40", Part2: "25272"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "one box", Input: "1,2,3\n", Line: 0, Column: 0},
+		{Name: "fields", Input: "1,2,3\n4,5\n", Line: 2, Column: 1},
+		{Name: "z", Input: "1,2,3\n\n4, 5, z\n", Line: 3, Column: 7},
+	})
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
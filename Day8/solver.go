@@ -0,0 +1,62 @@
+package day8
+
+import (
+	"io"
+	"sync"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's parser and circuit building to the aoc.Solver
+// interface.
+type Solver struct {
+	// Limit is the number of connections made before part 1 is measured;
+	// zero means defaultLimit.
+	Limit int
+}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 8 }
+
+// Parse implements aoc.Solver.
+func (s Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	points, err := parsePoints(r)
+	if err != nil {
+		return nil, err
+	}
+	limit := s.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	return &puzzle{points: points, limit: limit}, nil
+}
+
+type puzzle struct {
+	points []point
+	limit  int
+
+	// pairs is sorted by whichever part runs first and shared with the
+	// other, so the sort counts as solve time but is only paid once.
+	sortOnce sync.Once
+	pairs    []pair
+}
+
+func (p *puzzle) sortedPairs() []pair {
+	p.sortOnce.Do(func() { p.pairs = sortedPairs(p.points) })
+	return p.pairs
+}
+
+func (p *puzzle) Part1() (aoc.Result, error) {
+	return aoc.Int(circuitProduct(len(p.points), p.sortedPairs(), p.limit)), nil
+}
+
+func (p *puzzle) Part2() (aoc.Result, error) {
+	product, err := finalConnection(p.points, p.sortedPairs())
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(product), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,18 @@
+package day8
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// synthetic generates scale*10 junction boxes scattered through a
+// 100000-unit cube. Solving is quadratic in the number of boxes.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	var buf bytes.Buffer
+	for i := 0; i < scale*10; i++ {
+		fmt.Fprintf(&buf, "%d,%d,%d\n", rng.Intn(100000), rng.Intn(100000), rng.Intn(100000))
+	}
+	return buf.Bytes()
+}
@@ -1,13 +1,15 @@
-package main
+// Package day9 solves Advent of Code 2025 day 9: Movie Theater.
+package day9
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
+
+	"aoc25/aoc"
 )
 
 type point struct {
@@ -15,47 +17,25 @@ type point struct {
 	y int
 }
 
-func main() {
-	path := resolveInputPath(os.Args)
-
-	file, err := os.Open(path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
-		os.Exit(1)
-	}
-	defer file.Close()
-
-	part1, part2, err := Solve(file)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "solve error: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Part 1: %d\n", part1)
-	fmt.Printf("Part 2: %d\n", part2)
-}
-
-func resolveInputPath(args []string) string {
-	if len(args) > 1 {
-		return args[1]
-	}
-	if _, err := os.Stat("Day9/input.txt"); err == nil {
-		return "Day9/input.txt"
-	}
-	return "input.txt"
-}
-
 func Solve(r io.Reader) (int64, int64, error) {
 	pts, err := parsePoints(r)
 	if err != nil {
 		return 0, 0, err
 	}
-	if len(pts) < 2 {
-		return 0, 0, fmt.Errorf("need at least two points")
-	}
 
 	part1 := maxRectangleAny(pts)
+	part2, err := maxRectangleInLoop(pts)
+	if err != nil {
+		return 0, 0, err
+	}
 
+	return part1, part2, nil
+}
+
+// maxRectangleInLoop finds the largest rectangle with red corners that lies
+// entirely inside the loop, using coordinate compression and a prefix sum of
+// the inside cells.
+func maxRectangleInLoop(pts []point) (int64, error) {
 	xVals := make([]int, len(pts))
 	yVals := make([]int, len(pts))
 	for i, p := range pts {
@@ -66,7 +46,7 @@ func Solve(r io.Reader) (int64, int64, error) {
 	ys := uniqueSorted(yVals)
 
 	if len(xs) < 2 || len(ys) < 2 {
-		return part1, 0, nil
+		return 0, nil
 	}
 
 	xIndex := make(map[int]int, len(xs))
@@ -80,40 +60,43 @@ func Solve(r io.Reader) (int64, int64, error) {
 
 	inside, err := buildInsideGrid(pts, xs, ys, xIndex)
 	if err != nil {
-		return 0, 0, err
+		return 0, err
 	}
 	prefix := buildPrefix(inside)
 
-	part2 := maxRectangleInside(pts, xIndex, yIndex, prefix, xs, ys)
-
-	return part1, part2, nil
+	return maxRectangleInside(pts, xIndex, yIndex, prefix, xs, ys), nil
 }
 
 func parsePoints(r io.Reader) ([]point, error) {
 	scanner := bufio.NewScanner(r)
 	var pts []point
+	lineNumber := 0
 	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
+		lineNumber++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
-		parts := strings.Split(line, ",")
+		parts, cols := aoc.SplitColumns(line, ",")
 		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid coordinate %q", line)
+			return nil, aoc.ParseErrorf(lineNumber, cols[0], line, "invalid coordinate %q", strings.TrimSpace(line))
 		}
-		x, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		x, err := strconv.Atoi(parts[0])
 		if err != nil {
-			return nil, fmt.Errorf("invalid x in %q: %w", line, err)
+			return nil, aoc.ParseErrorf(lineNumber, cols[0], line, "invalid x: %w", err)
 		}
-		y, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		y, err := strconv.Atoi(parts[1])
 		if err != nil {
-			return nil, fmt.Errorf("invalid y in %q: %w", line, err)
+			return nil, aoc.ParseErrorf(lineNumber, cols[1], line, "invalid y: %w", err)
 		}
 		pts = append(pts, point{x: x, y: y})
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
+	if len(pts) < 2 {
+		return nil, &aoc.ParseError{Msg: "need at least two points"}
+	}
 	return pts, nil
 }
 
@@ -0,0 +1,29 @@
+package day9
+
+import (
+	"embed"
+	"testing"
+
+	"aoc25/aoc/aoctest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestSolveSamples(t *testing.T) {
+	aoctest.Run(t, testdata, Solver{}, []aoctest.Case{
+		{File: "sample.txt", Part1: "50", Part2: "24"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	aoctest.RunParseErrors(t, Solver{}, []aoctest.BadInput{
+		{Name: "one point", Input: "7,1\n", Line: 0, Column: 0},
+		{Name: "fields", Input: "7,1\n11,1,3\n", Line: 2, Column: 1},
+		{Name: "y", Input: "7,1\n 11,y\n", Line: 2, Column: 5},
+	})
+}
+
+func BenchmarkSolve(b *testing.B) {
+	aoctest.Benchmark(b, Solver{})
+}
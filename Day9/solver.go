@@ -0,0 +1,42 @@
+package day9
+
+import (
+	"io"
+
+	"aoc25/aoc"
+)
+
+// Solver adapts the day's parser and rectangle searches to the aoc.Solver
+// interface.
+type Solver struct{}
+
+// Day implements aoc.Solver.
+func (Solver) Day() int { return 9 }
+
+// Parse implements aoc.Solver.
+func (Solver) Parse(r io.Reader) (aoc.Puzzle, error) {
+	pts, err := parsePoints(r)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle{pts: pts}, nil
+}
+
+type puzzle struct {
+	pts []point
+}
+
+func (p puzzle) Part1() (aoc.Result, error) {
+	return aoc.Int(maxRectangleAny(p.pts)), nil
+}
+
+func (p puzzle) Part2() (aoc.Result, error) {
+	area, err := maxRectangleInLoop(p.pts)
+	if err != nil {
+		return aoc.Result{}, err
+	}
+	return aoc.Int(area), nil
+}
+
+// Synthetic implements aoc.Synthesizer.
+func (Solver) Synthetic(scale int) []byte { return synthetic(scale) }
@@ -0,0 +1,30 @@
+package day9
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// synthetic generates the outline of a histogram with scale*10 bars as the
+// loop of red tiles, so consecutive tiles always share a row or column.
+func synthetic(scale int) []byte {
+	rng := rand.New(rand.NewSource(int64(scale)))
+	bars := scale * 10
+	var buf bytes.Buffer
+	x := 0
+	fmt.Fprintf(&buf, "%d,%d\n", x, 0)
+	prev := -1
+	for i := 0; i < bars; i++ {
+		h := 1 + rng.Intn(10000)
+		for h == prev {
+			h = 1 + rng.Intn(10000)
+		}
+		prev = h
+		fmt.Fprintf(&buf, "%d,%d\n", x, h)
+		x += 1 + rng.Intn(100)
+		fmt.Fprintf(&buf, "%d,%d\n", x, h)
+	}
+	fmt.Fprintf(&buf, "%d,%d\n", x, 0)
+	return buf.Bytes()
+}
@@ -0,0 +1,115 @@
+// Package aoctest runs a day's solver against the sample fixtures embedded
+// from its testdata directory and benchmarks it on synthetic inputs.
+package aoctest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+
+	"aoc25/aoc"
+)
+
+// Case pairs a fixture in testdata with its expected answers. An empty part
+// means the solver must not answer that part (the zero aoc.Result).
+type Case struct {
+	File  string
+	Part1 string
+	Part2 string
+}
+
+// Run solves every case with s, reading File from the testdata directory of
+// fsys, and checks both parts. A case whose fixture is missing is skipped so
+// the reason shows up in go test -v instead of failing the whole package.
+func Run(t *testing.T, fsys fs.FS, s aoc.Solver, cases []Case) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.File, func(t *testing.T) {
+			f, err := fsys.Open(path.Join("testdata", tc.File))
+			if errors.Is(err, fs.ErrNotExist) {
+				t.Skipf("fixture testdata/%s not found; add it to run this case", tc.File)
+			}
+			if err != nil {
+				t.Fatalf("open fixture: %v", err)
+			}
+			defer f.Close()
+
+			part1, part2, err := aoc.Solve(s, f)
+			if err != nil {
+				t.Fatalf("Solve(%s) error = %v", tc.File, err)
+			}
+			checkPart(t, 1, part1, tc.Part1)
+			checkPart(t, 2, part2, tc.Part2)
+		})
+	}
+}
+
+func checkPart(t *testing.T, n int, got aoc.Result, want string) {
+	t.Helper()
+	if want == "" {
+		if !got.IsZero() {
+			t.Errorf("part%d = %s, want no answer", n, got)
+		}
+		return
+	}
+	if !got.Equal(aoc.Text(want)) {
+		t.Errorf("part%d = %s, want %s", n, got, want)
+	}
+}
+
+// BadInput is malformed input that Parse must reject with an
+// *aoc.ParseError at Line and Column.
+type BadInput struct {
+	Name   string
+	Input  string
+	Line   int
+	Column int
+}
+
+// RunParseErrors parses every bad input with s and checks that the error is
+// an *aoc.ParseError pointing at the expected position.
+func RunParseErrors(t *testing.T, s aoc.Solver, cases []BadInput) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := s.Parse(strings.NewReader(tc.Input))
+			var pe *aoc.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("Parse() error = %v, want *aoc.ParseError", err)
+			}
+			if pe.Line != tc.Line || pe.Column != tc.Column {
+				t.Errorf("Parse() error at %d:%d, want %d:%d (%v)", pe.Line, pe.Column, tc.Line, tc.Column, err)
+			}
+		})
+	}
+}
+
+// Benchmark runs SolveBenchmark for s at every scale in aoc.BenchScales as
+// sub-benchmarks named scale=N.
+func Benchmark(b *testing.B, s aoc.Solver) {
+	synth, ok := s.(aoc.Synthesizer)
+	if !ok {
+		b.Skipf("day %d solver cannot generate synthetic inputs", s.Day())
+	}
+	for _, scale := range aoc.BenchScales {
+		b.Run(fmt.Sprintf("scale=%d", scale), SolveBenchmark(s, synth.Synthetic(scale)))
+	}
+}
+
+// SolveBenchmark returns a benchmark that parses input with s and solves both
+// parts on every iteration.
+func SolveBenchmark(s aoc.Solver, input []byte) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(input)))
+		for i := 0; i < b.N; i++ {
+			if _, _, err := aoc.Solve(s, bytes.NewReader(input)); err != nil {
+				b.Fatalf("day %d: %v", s.Day(), err)
+			}
+		}
+	}
+}
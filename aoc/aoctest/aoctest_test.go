@@ -0,0 +1,40 @@
+package aoctest
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"aoc25/aoc"
+)
+
+// lineCounter answers part 1 with the number of input lines and leaves part 2
+// unanswered.
+type lineCounter struct{}
+
+func (lineCounter) Day() int { return 0 }
+
+func (lineCounter) Parse(r io.Reader) (aoc.Puzzle, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return lineCount(strings.Count(string(data), "\n")), nil
+}
+
+type lineCount int
+
+func (n lineCount) Part1() (aoc.Result, error) { return aoc.Int(int64(n)), nil }
+
+func (n lineCount) Part2() (aoc.Result, error) { return aoc.Result{}, nil }
+
+func TestRun(t *testing.T) {
+	fsys := fstest.MapFS{
+		"testdata/sample.txt": {Data: []byte("a\nb\nc\n")},
+	}
+	Run(t, fsys, lineCounter{}, []Case{
+		{File: "sample.txt", Part1: "3"},
+		{File: "missing.txt", Part1: "1"},
+	})
+}
@@ -0,0 +1,139 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrCanceled is matched by every *CanceledError, so callers can test for a
+// canceled solve with errors.Is without caring about the progress details.
+var ErrCanceled = errors.New("solve canceled")
+
+// CanceledError reports a part that stopped because its context was done,
+// along with how far it got. It unwraps to the context's error, so
+// errors.Is(err, context.DeadlineExceeded) works as well.
+type CanceledError struct {
+	// Done of Total units (regions, machines, ...) were fully evaluated.
+	Done  int
+	Total int
+	Unit  string
+	// Partial is the answer accumulated over the Done units.
+	Partial Result
+	Err     error
+}
+
+func (e *CanceledError) Error() string {
+	msg := fmt.Sprintf("canceled after %d/%d %s", e.Done, e.Total, e.Unit)
+	if !e.Partial.IsZero() {
+		msg += fmt.Sprintf(" (partial answer %s)", e.Partial)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is reports whether target is ErrCanceled.
+func (e *CanceledError) Is(target error) bool {
+	return target == ErrCanceled
+}
+
+// Unwrap returns the context's error.
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// ContextPuzzle is implemented by puzzles whose parts run searches long
+// enough to be worth interrupting. The parts return a *CanceledError once
+// ctx is done.
+type ContextPuzzle interface {
+	Puzzle
+	Part1Context(ctx context.Context) (Result, error)
+	Part2Context(ctx context.Context) (Result, error)
+}
+
+// SolveContext is Solve with cancellation: parts of a ContextPuzzle are
+// given ctx, other puzzles are only checked between parts.
+func SolveContext(ctx context.Context, s Solver, r io.Reader) (part1, part2 Result, err error) {
+	p, err := s.Parse(r)
+	if err != nil {
+		return Result{}, Result{}, err
+	}
+	parts := PartsContext(ctx, p)
+	for i, part := range parts {
+		if err := ctx.Err(); err != nil {
+			return Result{}, Result{}, fmt.Errorf("part %d: %w", i+1, &CanceledError{Done: i, Total: len(parts), Unit: "parts", Err: err})
+		}
+		res, err := part()
+		if err != nil {
+			return Result{}, Result{}, fmt.Errorf("part %d: %w", i+1, err)
+		}
+		if i == 0 {
+			part1 = res
+		} else {
+			part2 = res
+		}
+	}
+	return part1, part2, nil
+}
+
+// PartsContext returns both parts of p bound to ctx when p supports it and
+// the plain parts otherwise.
+func PartsContext(ctx context.Context, p Puzzle) [2]func() (Result, error) {
+	if cp, ok := p.(ContextPuzzle); ok {
+		return [2]func() (Result, error){
+			func() (Result, error) { return cp.Part1Context(ctx) },
+			func() (Result, error) { return cp.Part2Context(ctx) },
+		}
+	}
+	return [2]func() (Result, error){p.Part1, p.Part2}
+}
+
+// interruptInterval is how many Stopped calls pass between context checks.
+const interruptInterval = 1024
+
+// Interrupt lets a tight search loop poll for cancellation without paying
+// for a context check on every node. A nil *Interrupt never stops, which is
+// what NewInterrupt returns for contexts that cannot be canceled.
+type Interrupt struct {
+	ctx   context.Context
+	calls int
+	err   error
+}
+
+// NewInterrupt returns an Interrupt for ctx, or nil if ctx is never done.
+func NewInterrupt(ctx context.Context) *Interrupt {
+	if ctx.Done() == nil {
+		return nil
+	}
+	return &Interrupt{ctx: ctx}
+}
+
+// Stopped reports whether the context was found to be done. Once it returns
+// true it keeps doing so, so a search can unwind by checking it at every
+// level.
+func (in *Interrupt) Stopped() bool {
+	if in == nil {
+		return false
+	}
+	if in.err != nil {
+		return true
+	}
+	in.calls++
+	if in.calls%interruptInterval != 0 {
+		return false
+	}
+	in.err = in.ctx.Err()
+	return in.err != nil
+}
+
+// Err returns the context's error once Stopped has seen it. A search that
+// ran to completion is never reported as canceled.
+func (in *Interrupt) Err() error {
+	if in == nil {
+		return nil
+	}
+	return in.err
+}
@@ -0,0 +1,66 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCanceledErrorMatches(t *testing.T) {
+	err := error(&CanceledError{Done: 2, Total: 5, Unit: "regions", Partial: Int(1), Err: context.DeadlineExceeded})
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("errors.Is(err, ErrCanceled) = false")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("errors.Is(err, context.DeadlineExceeded) = false")
+	}
+	want := "canceled after 2/5 regions (partial answer 1): context deadline exceeded"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestInterrupt(t *testing.T) {
+	if in := NewInterrupt(context.Background()); in != nil || in.Stopped() || in.Err() != nil {
+		t.Fatalf("NewInterrupt(Background) = %v, want a nil Interrupt that never stops", in)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := NewInterrupt(ctx)
+	cancel()
+	stopped := false
+	for i := 0; i < interruptInterval && !stopped; i++ {
+		stopped = in.Stopped()
+	}
+	if !stopped || !errors.Is(in.Err(), context.Canceled) {
+		t.Fatalf("Interrupt did not stop within %d calls after cancel", interruptInterval)
+	}
+}
+
+// slowSolver's puzzle has plain parts only, so SolveContext can only check
+// the context between them.
+type slowSolver struct{ cancel func() }
+
+func (slowSolver) Day() int { return 0 }
+
+func (s slowSolver) Parse(io.Reader) (Puzzle, error) { return slowPuzzle(s), nil }
+
+type slowPuzzle struct{ cancel func() }
+
+func (p slowPuzzle) Part1() (Result, error) {
+	p.cancel()
+	return Int(1), nil
+}
+
+func (slowPuzzle) Part2() (Result, error) { return Int(2), nil }
+
+func TestSolveContextBetweenParts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, _, err := SolveContext(ctx, slowSolver{cancel: cancel}, strings.NewReader(""))
+	if !errors.Is(err, ErrCanceled) || !strings.HasPrefix(err.Error(), "part 2:") {
+		t.Fatalf("SolveContext() error = %v, want part 2 canceled", err)
+	}
+}
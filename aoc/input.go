@@ -0,0 +1,29 @@
+// Package aoc holds the helpers shared by every day's solver and by the
+// aoc runner command.
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// InputPath picks the puzzle input for a day. The first positional argument
+// wins; otherwise DayN/input.txt is used when running from the repository
+// root, falling back to input.txt in the working directory.
+func InputPath(day int, args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	path := DefaultInputPath(day)
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	return "input.txt"
+}
+
+// DefaultInputPath returns the conventional input location for a day relative
+// to the repository root.
+func DefaultInputPath(day int) string {
+	return filepath.Join(fmt.Sprintf("Day%d", day), "input.txt")
+}
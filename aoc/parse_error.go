@@ -0,0 +1,151 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError reports malformed puzzle input at a position. Line and Column
+// are 1-based; zero means the position is unknown, e.g. for an empty input.
+// File is left empty by the parsers and filled in by whoever opened the
+// input.
+type ParseError struct {
+	File    string
+	Line    int
+	Column  int
+	Snippet string
+	Msg     string
+	Err     error
+}
+
+// ParseErrorf builds a ParseError at line and column of snippet. The message
+// is formatted like fmt.Errorf, so a %w verb sets the wrapped error.
+func ParseErrorf(line, column int, snippet, format string, args ...any) *ParseError {
+	err := fmt.Errorf(format, args...)
+	return &ParseError{
+		Line:    line,
+		Column:  column,
+		Snippet: snippet,
+		Msg:     err.Error(),
+		Err:     errors.Unwrap(err),
+	}
+}
+
+// Error renders the error compiler-style: "input.txt:12:5: invalid distance".
+func (e *ParseError) Error() string {
+	return e.Position() + ": " + e.Msg
+}
+
+// Unwrap returns the underlying cause, if any.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Position formats the location as file:line:column, leaving out the parts
+// that are unknown.
+func (e *ParseError) Position() string {
+	var b strings.Builder
+	b.WriteString(e.File)
+	if b.Len() == 0 {
+		b.WriteString("input")
+	}
+	if e.Line > 0 {
+		b.WriteString(":" + strconv.Itoa(e.Line))
+		if e.Column > 0 {
+			b.WriteString(":" + strconv.Itoa(e.Column))
+		}
+	}
+	return b.String()
+}
+
+// snippetContext is how many bytes of a long line are shown either side of
+// the reported column.
+const snippetContext = 40
+
+// Detail returns the offending line with a caret under the column, or an
+// empty string when there is no snippet. Long lines are trimmed around the
+// column.
+func (e *ParseError) Detail() string {
+	if e.Snippet == "" {
+		return ""
+	}
+	line := e.Snippet
+	col := e.Column
+	if col > 0 && len(line) > 2*snippetContext {
+		start := col - 1 - snippetContext
+		if start < 0 {
+			start = 0
+		}
+		end := start + 2*snippetContext
+		if end > len(line) {
+			end = len(line)
+		}
+		line = line[start:end]
+		col -= start
+	}
+	if col <= 0 {
+		return line
+	}
+	// Keep tabs so the caret lines up with the snippet in a terminal.
+	var pad strings.Builder
+	for i := 0; i < col-1 && i < len(line); i++ {
+		if line[i] == '\t' {
+			pad.WriteByte('\t')
+		} else {
+			pad.WriteByte(' ')
+		}
+	}
+	return line + "\n" + pad.String() + "^"
+}
+
+// AtLine places an error from a single-line parser on its input line. A
+// *ParseError keeps its column, shifted right by offset for text trimmed off
+// the front of the line, and gains the line number and snippet. Any other
+// error becomes a ParseError covering the whole line.
+func AtLine(err error, line, offset int, snippet string) error {
+	if err == nil {
+		return nil
+	}
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		located := *pe
+		located.Line = line
+		located.Snippet = snippet
+		if located.Column > 0 {
+			located.Column += offset
+		}
+		return &located
+	}
+	return &ParseError{Line: line, Snippet: snippet, Msg: err.Error(), Err: err}
+}
+
+// SetFile records the input file name on every ParseError in err's chain
+// that does not have one yet.
+func SetFile(err error, file string) {
+	for err != nil {
+		if pe, ok := err.(*ParseError); ok && pe.File == "" {
+			pe.File = file
+		}
+		err = errors.Unwrap(err)
+	}
+}
+
+// LeadingSpace returns the number of bytes strings.TrimSpace would remove
+// from the front of s, which is the column offset of the trimmed text.
+func LeadingSpace(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " \t\r\n\v\f"))
+}
+
+// SplitColumns splits line around sep and trims each field, returning the
+// 1-based column where each trimmed field starts so errors can point at it.
+func SplitColumns(line, sep string) (fields []string, cols []int) {
+	offset := 0
+	for _, field := range strings.Split(line, sep) {
+		cols = append(cols, offset+LeadingSpace(field)+1)
+		fields = append(fields, strings.TrimSpace(field))
+		offset += len(field) + len(sep)
+	}
+	return fields, cols
+}
@@ -0,0 +1,76 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestParseErrorError(t *testing.T) {
+	tests := []struct {
+		err  *ParseError
+		want string
+	}{
+		{&ParseError{File: "input.txt", Line: 12, Column: 5, Msg: "invalid distance"}, "input.txt:12:5: invalid distance"},
+		{&ParseError{Line: 3, Msg: "bad line"}, "input:3: bad line"},
+		{&ParseError{File: "in.txt", Msg: "input is empty"}, "in.txt: input is empty"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestParseErrorfWraps(t *testing.T) {
+	_, cause := strconv.Atoi("x")
+	err := fmt.Errorf("parse: %w", ParseErrorf(2, 3, "L x", "invalid distance: %w", cause))
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("errors.As failed for %v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("errors.Is(err, strconv.ErrSyntax) = false, want true")
+	}
+
+	SetFile(err, "input.txt")
+	if pe.File != "input.txt" {
+		t.Errorf("File = %q after SetFile, want input.txt", pe.File)
+	}
+}
+
+func TestParseErrorDetail(t *testing.T) {
+	pe := &ParseError{Line: 1, Column: 3, Snippet: "R1x"}
+	if got, want := pe.Detail(), "R1x\n  ^"; got != want {
+		t.Errorf("Detail() = %q, want %q", got, want)
+	}
+}
+
+func TestAtLine(t *testing.T) {
+	err := AtLine(ParseErrorf(0, 4, "", "bad"), 7, 2, "  abcdef")
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("AtLine returned %T, want *ParseError", err)
+	}
+	if pe.Line != 7 || pe.Column != 6 || pe.Snippet != "  abcdef" {
+		t.Errorf("AtLine = %d:%d %q, want 7:6 %q", pe.Line, pe.Column, pe.Snippet, "  abcdef")
+	}
+
+	err = AtLine(errors.New("plain"), 2, 0, "x")
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Column != 0 {
+		t.Errorf("AtLine(plain) = %v, want a whole-line ParseError on line 2", err)
+	}
+}
+
+func TestSplitColumns(t *testing.T) {
+	fields, cols := SplitColumns(" 1, 22 ,3", ",")
+	wantFields := []string{"1", "22", "3"}
+	wantCols := []int{2, 5, 9}
+	for i := range wantFields {
+		if fields[i] != wantFields[i] || cols[i] != wantCols[i] {
+			t.Fatalf("SplitColumns = %q %v, want %q %v", fields, cols, wantFields, wantCols)
+		}
+	}
+}
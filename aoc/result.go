@@ -0,0 +1,93 @@
+package aoc
+
+import (
+	"math/big"
+	"strconv"
+)
+
+type resultKind uint8
+
+const (
+	kindNone resultKind = iota
+	kindInt
+	kindBig
+	kindText
+)
+
+// Result is one part's answer. It carries an int64, a *big.Int or a string so
+// tooling can print and compare answers without knowing each day's types. The
+// zero Result means "no answer".
+type Result struct {
+	kind resultKind
+	i    int64
+	b    *big.Int
+	s    string
+}
+
+// Int wraps an integer answer.
+func Int(v int64) Result {
+	return Result{kind: kindInt, i: v}
+}
+
+// BigInt wraps an arbitrary-precision answer. A nil value yields the zero
+// Result.
+func BigInt(v *big.Int) Result {
+	if v == nil {
+		return Result{}
+	}
+	return Result{kind: kindBig, b: new(big.Int).Set(v)}
+}
+
+// Text wraps an answer that is not a number.
+func Text(v string) Result {
+	return Result{kind: kindText, s: v}
+}
+
+// IsZero reports whether r holds no answer.
+func (r Result) IsZero() bool {
+	return r.kind == kindNone
+}
+
+// Int64 returns the answer as an int64 when it is an integer that fits.
+func (r Result) Int64() (int64, bool) {
+	switch r.kind {
+	case kindInt:
+		return r.i, true
+	case kindBig:
+		if r.b.IsInt64() {
+			return r.b.Int64(), true
+		}
+	}
+	return 0, false
+}
+
+// Big returns the answer as a new *big.Int, or nil when it is not an integer.
+func (r Result) Big() *big.Int {
+	switch r.kind {
+	case kindInt:
+		return big.NewInt(r.i)
+	case kindBig:
+		return new(big.Int).Set(r.b)
+	}
+	return nil
+}
+
+// String renders the answer the way the puzzle site expects it. The zero
+// Result renders as an empty string.
+func (r Result) String() string {
+	switch r.kind {
+	case kindInt:
+		return strconv.FormatInt(r.i, 10)
+	case kindBig:
+		return r.b.String()
+	case kindText:
+		return r.s
+	}
+	return ""
+}
+
+// Equal reports whether two answers print identically, so Int(40) equals
+// BigInt(big.NewInt(40)) and Text("40").
+func (r Result) Equal(other Result) bool {
+	return r.kind != kindNone && other.kind != kindNone && r.String() == other.String()
+}
@@ -0,0 +1,56 @@
+package aoc
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestResultString(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []struct {
+		r    Result
+		want string
+	}{
+		{Int(-42), "-42"},
+		{BigInt(huge), "123456789012345678901234567890"},
+		{Text("ABC"), "ABC"},
+		{Result{}, ""},
+		{BigInt(nil), ""},
+	}
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.want {
+			t.Fatalf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestResultEqual(t *testing.T) {
+	if !Int(40).Equal(BigInt(big.NewInt(40))) {
+		t.Fatalf("Int(40) should equal BigInt(40)")
+	}
+	if !Int(40).Equal(Text("40")) {
+		t.Fatalf("Int(40) should equal Text(\"40\")")
+	}
+	if Int(40).Equal(Int(41)) {
+		t.Fatalf("Int(40) should not equal Int(41)")
+	}
+	if (Result{}).Equal(Text("")) {
+		t.Fatalf("zero Result should not equal anything")
+	}
+}
+
+func TestResultConversions(t *testing.T) {
+	huge, _ := new(big.Int).SetString("99999999999999999999", 10)
+	if _, ok := BigInt(huge).Int64(); ok {
+		t.Fatalf("Int64() on overflowing value reported ok")
+	}
+	if v, ok := BigInt(big.NewInt(7)).Int64(); !ok || v != 7 {
+		t.Fatalf("Int64() = %d, %v, want 7, true", v, ok)
+	}
+	if b := Int(9).Big(); b == nil || b.Int64() != 9 {
+		t.Fatalf("Big() = %v, want 9", b)
+	}
+	if b := Text("x").Big(); b != nil {
+		t.Fatalf("Big() on text = %v, want nil", b)
+	}
+}
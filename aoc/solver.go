@@ -0,0 +1,107 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Solver is implemented by every day so runners, benchmarks and answer checks
+// can treat the days uniformly.
+type Solver interface {
+	// Day returns the puzzle day number.
+	Day() int
+	// Parse reads the puzzle input. Parsing is kept apart from solving so
+	// the two can be timed separately.
+	Parse(r io.Reader) (Puzzle, error)
+}
+
+// Puzzle is a parsed input that can be solved one part at a time.
+type Puzzle interface {
+	Part1() (Result, error)
+	Part2() (Result, error)
+}
+
+// Solve parses r with s and solves both parts.
+func Solve(s Solver, r io.Reader) (part1, part2 Result, err error) {
+	p, err := s.Parse(r)
+	if err != nil {
+		return Result{}, Result{}, err
+	}
+	part1, err = p.Part1()
+	if err != nil {
+		return Result{}, Result{}, fmt.Errorf("part 1: %w", err)
+	}
+	part2, err = p.Part2()
+	if err != nil {
+		return Result{}, Result{}, fmt.Errorf("part 2: %w", err)
+	}
+	return part1, part2, nil
+}
+
+// Main is the body of every DayN/cmd/dayN command: it resolves the input path
+// from the command line, solves it and prints every part that has an answer.
+func Main(s Solver) {
+	MainArgs(s, os.Args[1:])
+}
+
+// MainArgs is Main for commands that parse their own flags first: args are
+// the remaining positional arguments.
+func MainArgs(s Solver, args []string) {
+	path := InputPath(s.Day(), args)
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open input %q: %v\n", path, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	part1, part2, err := Solve(s, file)
+	if err != nil {
+		Fatal(path, err)
+	}
+
+	for i, part := range []Result{part1, part2} {
+		if !part.IsZero() {
+			fmt.Printf("Part %d: %s\n", i+1, part)
+		}
+	}
+}
+
+// Fatal reports err for the input at path and exits with status 1. Parse
+// errors are shown compiler-style with the offending line.
+func Fatal(path string, err error) {
+	SetFile(err, path)
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		fmt.Fprintln(os.Stderr, pe)
+		if detail := pe.Detail(); detail != "" {
+			fmt.Fprintln(os.Stderr, detail)
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "solve error: %v\n", err)
+	}
+	os.Exit(1)
+}
+
+// Synthesizer is implemented by solvers that can generate valid puzzle inputs
+// of any size, which is what the benchmarks run on.
+type Synthesizer interface {
+	// Synthetic returns an input whose size grows roughly linearly with
+	// scale. The same scale always yields the same input.
+	Synthetic(scale int) []byte
+}
+
+// BenchScales are the synthetic input scales every BenchmarkSolve and aoc
+// bench run.
+var BenchScales = []int{1, 10, 100}
+
+// StrictParser is implemented by solvers whose parser tolerates some
+// malformed input by default, skipping or repairing it, and can reject it
+// instead.
+type StrictParser interface {
+	// WithStrict returns a copy of the solver with strict parsing enabled.
+	WithStrict() Solver
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"aoc25/aoc"
+)
+
+const (
+	defaultBenchOut      = "bench/latest.txt"
+	defaultBenchBaseline = "bench/baseline.txt"
+)
+
+// benchUnits are the units compared against the baseline; a slowdown or
+// allocation increase above the threshold in any of them is a regression.
+var benchUnits = []string{"ns/op", "allocs/op"}
+
+func benchCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	benchTime := fs.Duration("benchtime", time.Second, "run each benchmark for `d`")
+	count := fs.Int("count", 1, "run each benchmark `n` times")
+	out := fs.String("out", defaultBenchOut, "write results to `file`")
+	baseline := fs.String("baseline", defaultBenchBaseline, "compare against the results in `file`")
+	threshold := fs.Float64("threshold", 10, "flag changes above `percent` as regressions")
+	save := fs.Bool("save-baseline", false, "also store the results as the new baseline")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *count < 1 {
+		fmt.Fprintln(stderr, "-count must be at least 1")
+		return 2
+	}
+
+	selectors := fs.Args()
+	if len(selectors) == 0 {
+		selectors = []string{"all"}
+	}
+	days, err := parseDays(selectors)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	var results bytes.Buffer
+	fmt.Fprintf(&results, "goos: %s\ngoarch: %s\npkg: aoc25\n", runtime.GOOS, runtime.GOARCH)
+	status := 0
+	for _, day := range days {
+		solver := registry[day]
+		synth, ok := solver.(aoc.Synthesizer)
+		if !ok {
+			fmt.Fprintf(stderr, "day %d: no synthetic input generator, skipping\n", day)
+			continue
+		}
+		for _, scale := range aoc.BenchScales {
+			if err := runBench(&results, stdout, solver, synth.Synthetic(scale), scale, *benchTime, *count); err != nil {
+				fmt.Fprintf(stderr, "day %d scale=%d: %v\n", day, scale, err)
+				status = 1
+			}
+		}
+	}
+
+	if err := writeBenchFile(*out, results.Bytes()); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "results written to %s\n", *out)
+
+	current, err := parseBench(bytes.NewReader(results.Bytes()))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	old, err := readBenchFile(*baseline)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		fmt.Fprintf(stdout, "no baseline at %s; rerun with -save-baseline to create one\n", *baseline)
+	case err != nil:
+		fmt.Fprintf(stderr, "read baseline: %v\n", err)
+		return 1
+	default:
+		fmt.Fprintln(stdout)
+		if regressions := compareBench(stdout, old, current, *threshold); regressions > 0 {
+			fmt.Fprintf(stdout, "%d regressions above %.1f%%\n", regressions, *threshold)
+			status = 1
+		}
+	}
+
+	if *save {
+		if err := writeBenchFile(*baseline, results.Bytes()); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "baseline saved to %s\n", *baseline)
+	}
+	return status
+}
+
+// runBench benchmarks one day at one scale count times, appending each
+// result line to results and echoing it to progress.
+func runBench(results *bytes.Buffer, progress io.Writer, s aoc.Solver, input []byte, scale int, benchTime time.Duration, count int) error {
+	name := fmt.Sprintf("BenchmarkSolve/day=%d/scale=%d", s.Day(), scale)
+	if procs := runtime.GOMAXPROCS(0); procs > 1 {
+		name = fmt.Sprintf("%s-%d", name, procs)
+	}
+	for i := 0; i < count; i++ {
+		res, err := benchmark(s, input, benchTime)
+		if err != nil {
+			return err
+		}
+		line := fmt.Sprintf("%s\t%s\n", name, res)
+		results.WriteString(line)
+		io.WriteString(progress, line)
+	}
+	return nil
+}
+
+// benchResult is n runs of a benchmark, with the time and allocations they
+// took in total.
+type benchResult struct {
+	n       int
+	elapsed time.Duration
+	// bytes is the size of the input each run reads.
+	bytes         int
+	allocs, alloc uint64
+}
+
+// String formats r the way go test -bench does, per run.
+func (r benchResult) String() string {
+	n := float64(r.n)
+	mbs := 0.0
+	if secs := r.elapsed.Seconds(); secs > 0 {
+		mbs = float64(r.bytes) * n / 1e6 / secs
+	}
+	return fmt.Sprintf("%8d\t%10.0f ns/op\t%7.2f MB/s\t%8.0f B/op\t%8.0f allocs/op",
+		r.n, float64(r.elapsed.Nanoseconds())/n, mbs, float64(r.alloc)/n, float64(r.allocs)/n)
+}
+
+// benchmark solves input with s over and over until the runs take at least
+// benchTime. Like go test, it starts with one run and retries with a count
+// predicted from the last timing, growing at most a hundredfold each time.
+func benchmark(s aoc.Solver, input []byte, benchTime time.Duration) (benchResult, error) {
+	const maxRuns = 1e9
+	n := 1
+	for {
+		res, err := timeSolve(s, input, n)
+		if err != nil || res.elapsed >= benchTime || n >= maxRuns {
+			return res, err
+		}
+		next := 100 * n
+		if res.elapsed > 0 {
+			// Aim a fifth past benchTime so the next try usually suffices.
+			next = int(min(1.2*float64(n)*float64(benchTime)/float64(res.elapsed), float64(next)))
+		}
+		n = min(max(next, n+1), maxRuns)
+	}
+}
+
+// timeSolve parses input with s and solves both parts n times.
+func timeSolve(s aoc.Solver, input []byte, n int) (benchResult, error) {
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		if _, _, err := aoc.Solve(s, bytes.NewReader(input)); err != nil {
+			return benchResult{}, err
+		}
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return benchResult{
+		n:       n,
+		elapsed: elapsed,
+		bytes:   len(input),
+		allocs:  after.Mallocs - before.Mallocs,
+		alloc:   after.TotalAlloc - before.TotalAlloc,
+	}, nil
+}
+
+func writeBenchFile(path string, data []byte) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// compareBench prints a benchstat-style table per unit comparing the median
+// of each benchmark with its baseline, and returns how many changes exceed
+// threshold percent in the worse direction.
+func compareBench(w io.Writer, old, current *benchSet, threshold float64) int {
+	regressions := 0
+	for _, unit := range benchUnits {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "name\told %s\tnew %s\tdelta\t\n", unit, unit)
+		for _, name := range current.names {
+			cur, ok := current.median(name, unit)
+			if !ok {
+				continue
+			}
+			base, ok := old.median(name, unit)
+			if !ok {
+				fmt.Fprintf(tw, "%s\t-\t%s\t(new)\t\n", name, formatBenchValue(cur, unit))
+				continue
+			}
+			delta, note := benchDelta(base, cur, threshold)
+			if note != "" {
+				regressions++
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, formatBenchValue(base, unit), formatBenchValue(cur, unit), delta, note)
+		}
+		tw.Flush()
+		fmt.Fprintln(w)
+	}
+	return regressions
+}
+
+// benchDelta formats the relative change from base to cur and returns a
+// REGRESSION note when it grows by more than threshold percent.
+func benchDelta(base, cur, threshold float64) (string, string) {
+	if base == 0 {
+		if cur == 0 {
+			return "~", ""
+		}
+		return "+inf%", "REGRESSION"
+	}
+	pct := (cur - base) / base * 100
+	note := ""
+	if pct > threshold {
+		note = "REGRESSION"
+	}
+	return fmt.Sprintf("%+.2f%%", pct), note
+}
+
+func formatBenchValue(v float64, unit string) string {
+	if unit == "ns/op" {
+		return roundDuration(time.Duration(v)).String()
+	}
+	return fmt.Sprintf("%.0f", v)
+}
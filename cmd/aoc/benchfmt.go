@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// benchSet holds benchmark measurements in the Go benchmark text format, the
+// same format go test -bench prints and benchstat reads. Each name maps to
+// every value recorded for a unit, one per run.
+type benchSet struct {
+	names  []string
+	values map[string]map[string][]float64
+}
+
+func newBenchSet() *benchSet {
+	return &benchSet{values: make(map[string]map[string][]float64)}
+}
+
+func (s *benchSet) add(name, unit string, value float64) {
+	units, ok := s.values[name]
+	if !ok {
+		units = make(map[string][]float64)
+		s.values[name] = units
+		s.names = append(s.names, name)
+	}
+	units[unit] = append(units[unit], value)
+}
+
+// median returns the median value recorded for name in unit.
+func (s *benchSet) median(name, unit string) (float64, bool) {
+	vals := s.values[name][unit]
+	if len(vals) == 0 {
+		return 0, false
+	}
+	sorted := append([]float64(nil), vals...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid], true
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2, true
+}
+
+// readBenchFile parses the benchmark results stored at path.
+func readBenchFile(path string) (*benchSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseBench(f)
+}
+
+// parseBench reads lines of the form
+//
+//	BenchmarkName-8  1000  1234 ns/op  56 B/op  7 allocs/op
+//
+// and ignores everything else. The -GOMAXPROCS suffix is dropped so results
+// from machines with different core counts still line up.
+func parseBench(r io.Reader) (*benchSet, error) {
+	set := newBenchSet()
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		name := trimProcs(fields[0])
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q", lineNumber, fields[i])
+			}
+			set.add(name, fields[i+1], value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func trimProcs(name string) string {
+	dash := strings.LastIndexByte(name, '-')
+	if dash == -1 {
+		return name
+	}
+	if _, err := strconv.Atoi(name[dash+1:]); err != nil {
+		return name
+	}
+	return name[:dash]
+}
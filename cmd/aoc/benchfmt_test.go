@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const sampleBench = `goos: linux
+goarch: amd64
+pkg: aoc25
+BenchmarkSolve/day=1/scale=1-8     	    9000	    120000 ns/op	   54536 B/op	      13 allocs/op
+BenchmarkSolve/day=1/scale=1-8     	    9000	    100000 ns/op	   54536 B/op	      13 allocs/op
+BenchmarkSolve/day=1/scale=1-8     	    9000	    110000 ns/op	   54536 B/op	      13 allocs/op
+BenchmarkSolve/day=8/scale=1-8     	    1000	   1000000 ns/op	    1000 B/op	     100 allocs/op
+PASS
+`
+
+func TestParseBench(t *testing.T) {
+	set, err := parseBench(strings.NewReader(sampleBench))
+	if err != nil {
+		t.Fatalf("parseBench() error = %v", err)
+	}
+	if len(set.names) != 2 || set.names[0] != "BenchmarkSolve/day=1/scale=1" {
+		t.Fatalf("names = %v, want day=1 and day=8 without procs suffix", set.names)
+	}
+	if got, ok := set.median("BenchmarkSolve/day=1/scale=1", "ns/op"); !ok || got != 110000 {
+		t.Fatalf("median ns/op = %v, %v, want 110000", got, ok)
+	}
+	if got, ok := set.median("BenchmarkSolve/day=8/scale=1", "allocs/op"); !ok || got != 100 {
+		t.Fatalf("median allocs/op = %v, %v, want 100", got, ok)
+	}
+}
+
+func TestCompareBenchFlagsRegressions(t *testing.T) {
+	old, err := parseBench(strings.NewReader(sampleBench))
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := parseBench(strings.NewReader(
+		"BenchmarkSolve/day=1/scale=1-4 9000 112000 ns/op 54536 B/op 13 allocs/op\n" +
+			"BenchmarkSolve/day=8/scale=1-4 1000 1500000 ns/op 1000 B/op 100 allocs/op\n" +
+			"BenchmarkSolve/day=9/scale=1-4 1000 1500 ns/op 10 B/op 1 allocs/op\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if got := compareBench(&out, old, current, 10); got != 1 {
+		t.Fatalf("compareBench() = %d regressions, want 1\n%s", got, out.String())
+	}
+	report := out.String()
+	if !strings.Contains(report, "+50.00%") || !strings.Contains(report, "REGRESSION") {
+		t.Fatalf("report missing day 8 regression:\n%s", report)
+	}
+	if !strings.Contains(report, "(new)") {
+		t.Fatalf("report missing new benchmark marker:\n%s", report)
+	}
+}
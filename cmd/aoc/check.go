@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"aoc25/aoc"
+)
+
+type checkStatus string
+
+const (
+	statusPass    checkStatus = "PASS"
+	statusFail    checkStatus = "FAIL"
+	statusMissing checkStatus = "MISSING"
+	statusError   checkStatus = "ERROR"
+	statusSkip    checkStatus = "SKIP"
+)
+
+// partCheck is the verdict for one day/part against the ledger.
+type partCheck struct {
+	day    int
+	part   int
+	status checkStatus
+	got    string
+	want   string
+}
+
+func checkCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	ledgerPath := fs.String("answers", defaultLedgerPath, "JSON ledger of expected answers")
+	record := fs.Bool("record", false, "store answers for MISSING parts in the ledger")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	selectors := fs.Args()
+	if len(selectors) == 0 {
+		selectors = []string{"all"}
+	}
+	days, err := parseDays(selectors)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	answers, err := loadLedger(*ledgerPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	counts := make(map[checkStatus]int)
+	recorded := 0
+	for _, day := range days {
+		path := aoc.DefaultInputPath(day)
+		run := solveDay(day, path, runOptions{})
+		var checks []partCheck
+		switch err := run.firstErr(); {
+		case errors.Is(err, os.ErrNotExist):
+			checks = []partCheck{{day: day, status: statusSkip, got: "no input at " + path}}
+		case err != nil:
+			checks = []partCheck{{day: day, status: statusError, got: err.Error()}}
+		default:
+			checks = checkParts(day, answers[day], run.parts[0].result, run.parts[1].result)
+		}
+		for _, c := range checks {
+			counts[c.status]++
+			printCheck(stdout, c)
+			if *record && c.status == statusMissing {
+				entry := answers[day]
+				entry.setPart(c.part, c.got)
+				answers[day] = entry
+				recorded++
+			}
+		}
+	}
+
+	fmt.Fprintf(stdout, "%d passed, %d failed, %d missing, %d errors, %d skipped\n",
+		counts[statusPass], counts[statusFail], counts[statusMissing], counts[statusError], counts[statusSkip])
+
+	if recorded > 0 {
+		if err := answers.save(*ledgerPath); err != nil {
+			fmt.Fprintf(stderr, "save ledger: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "recorded %d answers in %s\n", recorded, *ledgerPath)
+	}
+	if counts[statusFail] > 0 || counts[statusError] > 0 {
+		return 1
+	}
+	return 0
+}
+
+// checkParts compares both solved parts with the ledger entry for a day.
+// Parts the solver does not answer (the zero Result) are only reported when
+// the ledger expects something for them.
+func checkParts(day int, want dayAnswers, part1, part2 aoc.Result) []partCheck {
+	var checks []partCheck
+	for i, got := range []aoc.Result{part1, part2} {
+		n := i + 1
+		expected := want.part(n)
+		c := partCheck{day: day, part: n, got: got.String(), want: expected}
+		switch {
+		case got.IsZero() && expected == "":
+			continue
+		case expected == "":
+			c.status = statusMissing
+		case got.Equal(aoc.Text(expected)):
+			c.status = statusPass
+		default:
+			c.status = statusFail
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func printCheck(w io.Writer, c partCheck) {
+	label := fmt.Sprintf("day %2d part %d", c.day, c.part)
+	if c.part == 0 {
+		label = fmt.Sprintf("day %2d       ", c.day)
+	}
+	switch c.status {
+	case statusPass:
+		fmt.Fprintf(w, "%s  %-7s  %s\n", label, c.status, c.got)
+	case statusFail:
+		fmt.Fprintf(w, "%s  %-7s  got %s, want %s\n", label, c.status, c.got, c.want)
+	case statusMissing:
+		fmt.Fprintf(w, "%s  %-7s  got %s\n", label, c.status, c.got)
+	default:
+		fmt.Fprintf(w, "%s  %-7s  %s\n", label, c.status, c.got)
+	}
+}
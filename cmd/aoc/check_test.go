@@ -0,0 +1,62 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"aoc25/aoc"
+)
+
+func TestCheckParts(t *testing.T) {
+	want := dayAnswers{Part1: "3", Part2: "7"}
+	got := checkParts(1, want, aoc.Int(3), aoc.Int(6))
+	if len(got) != 2 {
+		t.Fatalf("checkParts() returned %d checks, want 2", len(got))
+	}
+	if got[0].status != statusPass {
+		t.Fatalf("part 1 status = %s, want %s", got[0].status, statusPass)
+	}
+	if got[1].status != statusFail || got[1].got != "6" || got[1].want != "7" {
+		t.Fatalf("part 2 check = %+v, want FAIL got 6 want 7", got[1])
+	}
+}
+
+func TestCheckPartsMissingAndUnanswered(t *testing.T) {
+	got := checkParts(12, dayAnswers{}, aoc.Int(2), aoc.Result{})
+	if len(got) != 1 {
+		t.Fatalf("checkParts() returned %d checks, want 1", len(got))
+	}
+	if got[0].status != statusMissing || got[0].got != "2" {
+		t.Fatalf("part 1 check = %+v, want MISSING got 2", got[0])
+	}
+
+	got = checkParts(12, dayAnswers{Part2: "1"}, aoc.Int(2), aoc.Result{})
+	if len(got) != 2 || got[1].status != statusFail {
+		t.Fatalf("unanswered part with ledger entry = %+v, want FAIL", got)
+	}
+}
+
+func TestLedgerRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	empty, err := loadLedger(path)
+	if err != nil {
+		t.Fatalf("loadLedger(missing) error = %v", err)
+	}
+	if len(empty) != 0 {
+		t.Fatalf("loadLedger(missing) = %v, want empty", empty)
+	}
+
+	want := ledger{1: {Part1: "3", Part2: "6"}, 12: {Part1: "2"}}
+	if err := want.save(path); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+	got, err := loadLedger(path)
+	if err != nil {
+		t.Fatalf("loadLedger() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("loadLedger() = %v, want %v", got, want)
+	}
+}
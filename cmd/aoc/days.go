@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseDays expands day selectors such as "7", "3-9", "1,4" or "all" into a
+// sorted, de-duplicated list of registered days.
+func parseDays(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no days given (use a day number, a range like 3-9, or all)")
+	}
+	selected := make(map[int]bool)
+	for _, arg := range args {
+		for _, sel := range strings.Split(arg, ",") {
+			sel = strings.TrimSpace(sel)
+			if sel == "" {
+				continue
+			}
+			if sel == "all" {
+				for _, day := range registeredDays() {
+					selected[day] = true
+				}
+				continue
+			}
+			lo, hi, err := parseDayRange(sel)
+			if err != nil {
+				return nil, err
+			}
+			for day := lo; day <= hi; day++ {
+				if _, ok := registry[day]; ok {
+					selected[day] = true
+				} else if lo == hi {
+					return nil, fmt.Errorf("day %d has no solver", day)
+				}
+			}
+		}
+	}
+	var days []int
+	for _, day := range registeredDays() {
+		if selected[day] {
+			days = append(days, day)
+		}
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no registered days match %q", strings.Join(args, " "))
+	}
+	return days, nil
+}
+
+func parseDayRange(sel string) (int, int, error) {
+	loText, hiText, isRange := strings.Cut(sel, "-")
+	lo, err := strconv.Atoi(loText)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid day %q", sel)
+	}
+	if !isRange {
+		return lo, lo, nil
+	}
+	hi, err := strconv.Atoi(hiText)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid day range %q", sel)
+	}
+	if lo > hi {
+		return 0, 0, fmt.Errorf("day range %q is reversed", sel)
+	}
+	return lo, hi, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []int
+	}{
+		{[]string{"7"}, []int{7}},
+		{[]string{"3-5"}, []int{3, 4, 5}},
+		{[]string{"9", "1,4"}, []int{1, 4, 9}},
+		{[]string{"2-3", "3"}, []int{2, 3}},
+		{[]string{"11-30"}, []int{11, 12}},
+		{[]string{"all"}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+	}
+	for _, tt := range tests {
+		got, err := parseDays(tt.args)
+		if err != nil {
+			t.Fatalf("parseDays(%q) error = %v", tt.args, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("parseDays(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestParseDaysInvalid(t *testing.T) {
+	for _, args := range [][]string{nil, {"x"}, {"9-3"}, {"40"}, {"20-30"}} {
+		if _, err := parseDays(args); err == nil {
+			t.Fatalf("parseDays(%q) error = nil, want error", args)
+		}
+	}
+}
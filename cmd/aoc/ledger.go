@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// defaultLedgerPath is where aoc check looks for expected answers. The file
+// is local to each checkout because answers depend on the personal inputs.
+const defaultLedgerPath = "answers.json"
+
+// dayAnswers holds the expected answers for one day; an empty string means
+// the answer has not been recorded yet.
+type dayAnswers struct {
+	Part1 string `json:"part1,omitempty"`
+	Part2 string `json:"part2,omitempty"`
+}
+
+func (a dayAnswers) part(n int) string {
+	if n == 1 {
+		return a.Part1
+	}
+	return a.Part2
+}
+
+func (a *dayAnswers) setPart(n int, answer string) {
+	if n == 1 {
+		a.Part1 = answer
+	} else {
+		a.Part2 = answer
+	}
+}
+
+// ledger maps a day number to its expected answers. It is stored as JSON:
+//
+//	{"1": {"part1": "1234", "part2": "5678"}}
+type ledger map[int]dayAnswers
+
+// loadLedger reads the ledger at path. A missing file yields an empty ledger
+// so every part is reported as MISSING rather than failing outright.
+func loadLedger(path string) (ledger, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	l := ledger{}
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("parse ledger %q: %w", path, err)
+	}
+	return l, nil
+}
+
+func (l ledger) save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
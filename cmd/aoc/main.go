@@ -0,0 +1,50 @@
+// Command aoc runs any of the registered Advent of Code 2025 solvers from a
+// single binary.
+//
+//	aoc run 7
+//	aoc run 3-9
+//	aoc run all
+//	aoc check
+//	aoc bench
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(dispatch(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func dispatch(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	switch args[0] {
+	case "run":
+		return runCommand(args[1:], stdout, stderr)
+	case "check":
+		return checkCommand(args[1:], stdout, stderr)
+	case "bench":
+		return benchCommand(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		usage(stdout)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: aoc <command> [flags] <days>")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	fmt.Fprintln(w, "  run    solve the selected days (e.g. 7, 3-9, all)")
+	fmt.Fprintln(w, "  check  compare answers with the ledger (default: all days)")
+	fmt.Fprintln(w, "  bench  benchmark solvers on synthetic inputs against a baseline (default: all days)")
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"aoc25/aoc"
+)
+
+// stage is one timed step of solving a day: parsing or one of the parts.
+type stage struct {
+	duration time.Duration
+	allocs   uint64
+	bytes    uint64
+}
+
+type partRun struct {
+	result aoc.Result
+	err    error
+	stage  stage
+}
+
+// dayRun is everything the runner learned from solving one day. err is set
+// when the input could not be opened or parsed; part failures are kept per
+// part so the other part still gets reported.
+type dayRun struct {
+	day      int
+	parse    stage
+	parts    [2]partRun
+	peakHeap uint64
+	err      error
+}
+
+// failed reports whether opening, parsing or either part failed.
+func (d dayRun) failed() bool {
+	return d.err != nil || d.parts[0].err != nil || d.parts[1].err != nil
+}
+
+// firstErr returns the first failure in solving order, or nil.
+func (d dayRun) firstErr() error {
+	if d.err != nil {
+		return d.err
+	}
+	for i, p := range d.parts {
+		if p.err != nil {
+			return fmt.Errorf("part %d: %w", i+1, p.err)
+		}
+	}
+	return nil
+}
+
+type runOptions struct {
+	// memStats collects allocation counts per stage and the peak heap for
+	// the day. It reads runtime.MemStats around every stage, so it is off
+	// unless timing was requested.
+	memStats bool
+	// strict switches solvers that implement aoc.StrictParser to strict
+	// parsing.
+	strict bool
+	// timeout bounds each day, parse included. Only parts that implement
+	// aoc.ContextPuzzle can be stopped once they are running.
+	timeout time.Duration
+}
+
+// solveDay parses the input at path with the day's solver and solves both
+// parts, timing each step separately.
+func solveDay(day int, path string, opts runOptions) dayRun {
+	run := dayRun{day: day}
+
+	file, err := os.Open(path)
+	if err != nil {
+		run.err = fmt.Errorf("failed to open input %q: %w", path, err)
+		return run
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	var sampler *heapSampler
+	if opts.memStats {
+		runtime.GC()
+		sampler = startHeapSampler()
+	}
+
+	solver := registry[day]
+	if sp, ok := solver.(aoc.StrictParser); ok && opts.strict {
+		solver = sp.WithStrict()
+	}
+
+	var puzzle aoc.Puzzle
+	run.parse = measure(opts.memStats, func() {
+		puzzle, err = solver.Parse(file)
+	})
+	if err != nil {
+		aoc.SetFile(err, path)
+		run.err = err
+	} else {
+		parts := aoc.PartsContext(ctx, puzzle)
+		for i, solve := range parts {
+			part := &run.parts[i]
+			// Parts that don't watch ctx themselves are stopped before they
+			// start, including when parsing used up the time.
+			if err := ctx.Err(); err != nil {
+				part.err = &aoc.CanceledError{Done: i, Total: len(parts), Unit: "parts", Err: err}
+				continue
+			}
+			part.stage = measure(opts.memStats, func() {
+				part.result, part.err = solve()
+			})
+		}
+	}
+
+	if sampler != nil {
+		run.peakHeap = sampler.stop()
+	}
+	return run
+}
+
+func measure(memStats bool, fn func()) stage {
+	var before, after runtime.MemStats
+	if memStats {
+		runtime.ReadMemStats(&before)
+	}
+	start := time.Now()
+	fn()
+	s := stage{duration: time.Since(start)}
+	if memStats {
+		runtime.ReadMemStats(&after)
+		s.allocs = after.Mallocs - before.Mallocs
+		s.bytes = after.TotalAlloc - before.TotalAlloc
+	}
+	return s
+}
+
+// heapMetric tracks live heap objects; reading it does not stop the world,
+// so it can be polled while a solver runs.
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// heapSampler polls the live heap size in the background and remembers the
+// largest value seen. Short spikes between samples can be missed, so the
+// peak is a lower bound.
+type heapSampler struct {
+	done chan struct{}
+	wg   sync.WaitGroup
+	peak uint64
+}
+
+func startHeapSampler() *heapSampler {
+	h := &heapSampler{done: make(chan struct{})}
+	h.sample()
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.done:
+				return
+			case <-ticker.C:
+				h.sample()
+			}
+		}
+	}()
+	return h
+}
+
+func (h *heapSampler) sample() {
+	samples := []metrics.Sample{{Name: heapMetric}}
+	metrics.Read(samples)
+	if samples[0].Value.Kind() != metrics.KindUint64 {
+		return
+	}
+	if v := samples[0].Value.Uint64(); v > h.peak {
+		h.peak = v
+	}
+}
+
+// stop ends sampling and returns the peak heap in bytes.
+func (h *heapSampler) stop() uint64 {
+	close(h.done)
+	h.wg.Wait()
+	h.sample()
+	return h.peak
+}
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n)
+	suffixes := []string{"KiB", "MiB", "GiB", "TiB"}
+	i := -1
+	for value >= unit && i < len(suffixes)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[i])
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aoc25/aoc"
+)
+
+func TestSolveDayStages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("L68\nL30\nR48\nL5\nR60\nL55\nL1\nL99\nR14\nL82\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := solveDay(1, path, runOptions{memStats: true})
+	if err := run.firstErr(); err != nil {
+		t.Fatalf("solveDay() error = %v", err)
+	}
+	if got := run.parts[0].result.String(); got != "3" {
+		t.Fatalf("part 1 = %s, want 3", got)
+	}
+	if got := run.parts[1].result.String(); got != "6" {
+		t.Fatalf("part 2 = %s, want 6", got)
+	}
+	if run.parse.allocs == 0 {
+		t.Fatalf("parse allocs = 0, want allocation counts with memStats")
+	}
+	if run.peakHeap == 0 {
+		t.Fatalf("peakHeap = 0, want a sampled heap size")
+	}
+}
+
+func TestSolveDayMissingInput(t *testing.T) {
+	run := solveDay(1, filepath.Join(t.TempDir(), "missing.txt"), runOptions{})
+	if !run.failed() {
+		t.Fatalf("solveDay() on missing input did not fail")
+	}
+}
+
+func TestSolveDayParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("L68\nL3x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := solveDay(1, path, runOptions{})
+	var pe *aoc.ParseError
+	if !errors.As(run.err, &pe) {
+		t.Fatalf("solveDay() error = %v, want *aoc.ParseError", run.err)
+	}
+
+	var out bytes.Buffer
+	printRunError(&out, 1, run.err)
+	want := path + ":2:2: invalid distance: strconv.Atoi: parsing \"3x\": invalid syntax\nL3x\n ^\n"
+	if out.String() != want {
+		t.Fatalf("printRunError() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestSolveDayStrict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("you: out\nnot a node\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if run := solveDay(11, path, runOptions{}); run.failed() {
+		t.Fatalf("lenient solveDay() error = %v", run.firstErr())
+	}
+	run := solveDay(11, path, runOptions{strict: true})
+	var pe *aoc.ParseError
+	if !errors.As(run.err, &pe) || pe.Line != 2 {
+		t.Fatalf("strict solveDay() error = %v, want a ParseError on line 2", run.err)
+	}
+}
+
+func TestSolveDayTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("L68\nL30\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Day 1's parts don't watch the context, so they have to be stopped
+	// before they start.
+	run := solveDay(1, path, runOptions{timeout: time.Nanosecond})
+	for i, part := range run.parts {
+		if !errors.Is(part.err, aoc.ErrCanceled) || !errors.Is(part.err, context.DeadlineExceeded) {
+			t.Errorf("part %d error = %v, want a deadline cancellation", i+1, part.err)
+		}
+		if !part.result.IsZero() {
+			t.Errorf("part %d = %v, want no answer", i+1, part.result)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[uint64]string{
+		0:       "0 B",
+		1023:    "1023 B",
+		1024:    "1.0 KiB",
+		1536:    "1.5 KiB",
+		5 << 20: "5.0 MiB",
+		3 << 30: "3.0 GiB",
+		1 << 50: "1024.0 TiB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Fatalf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	formatText   = "text"
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+// partRecord is the machine-readable result of one part. Answers are always
+// strings so arbitrary-precision answers survive JSON number handling.
+type partRecord struct {
+	Day        int    `json:"day"`
+	Part       int    `json:"part"`
+	Answer     string `json:"answer"`
+	DurationNS int64  `json:"duration_ns"`
+	Error      string `json:"error,omitempty"`
+}
+
+// partRecords flattens a day's run into one record per part. When the input
+// could not be read or parsed, both parts carry that error. Parts the day
+// does not answer are left out.
+func partRecords(run dayRun) []partRecord {
+	var records []partRecord
+	for i, part := range run.parts {
+		rec := partRecord{Day: run.day, Part: i + 1}
+		switch {
+		case run.err != nil:
+			rec.Error = run.err.Error()
+		case part.err != nil:
+			rec.Error = part.err.Error()
+			rec.DurationNS = part.stage.duration.Nanoseconds()
+		case part.result.IsZero():
+			continue
+		default:
+			rec.Answer = part.result.String()
+			rec.DurationNS = part.stage.duration.Nanoseconds()
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+// writeNDJSON writes one JSON object per line.
+func writeNDJSON(w io.Writer, records []partRecord) error {
+	enc := json.NewEncoder(w)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSON writes all records as a single indented JSON array.
+func writeJSON(w io.Writer, records []partRecord) error {
+	if records == nil {
+		records = []partRecord{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+func validFormat(format string) error {
+	switch format {
+	case formatText, formatJSON, formatNDJSON:
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (want text, json or ndjson)", format)
+}
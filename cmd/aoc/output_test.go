@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"aoc25/aoc"
+)
+
+func TestPartRecords(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	run := dayRun{day: 7}
+	run.parts[0] = partRun{result: aoc.Int(21), stage: stage{duration: 3 * time.Millisecond}}
+	run.parts[1] = partRun{result: aoc.BigInt(huge)}
+
+	var out bytes.Buffer
+	if err := writeNDJSON(&out, partRecords(run)); err != nil {
+		t.Fatalf("writeNDJSON() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d NDJSON lines, want 2:\n%s", len(lines), out.String())
+	}
+	var rec partRecord
+	if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil {
+		t.Fatalf("unmarshal %q: %v", lines[1], err)
+	}
+	if rec.Day != 7 || rec.Part != 2 || rec.Answer != huge.String() || rec.Error != "" {
+		t.Fatalf("part 2 record = %+v, want exact big answer", rec)
+	}
+	if !strings.Contains(lines[0], `"duration_ns":3000000`) {
+		t.Fatalf("part 1 record %s missing duration", lines[0])
+	}
+}
+
+func TestPartRecordsErrors(t *testing.T) {
+	run := dayRun{day: 12, err: errors.New("bad input")}
+	records := partRecords(run)
+	if len(records) != 2 || records[0].Error != "bad input" || records[1].Error != "bad input" {
+		t.Fatalf("records for parse failure = %+v, want the error on both parts", records)
+	}
+
+	run = dayRun{day: 12}
+	run.parts[0] = partRun{result: aoc.Int(2)}
+	records = partRecords(run)
+	if len(records) != 1 || records[0].Answer != "2" {
+		t.Fatalf("records = %+v, want only the answered part", records)
+	}
+
+	var out bytes.Buffer
+	if err := writeJSON(&out, records); err != nil {
+		t.Fatalf("writeJSON() error = %v", err)
+	}
+	var decoded []partRecord
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("writeJSON() output is not a JSON array: %v\n%s", err, out.String())
+	}
+}
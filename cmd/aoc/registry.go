@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"aoc25/Day1"
+	"aoc25/Day10"
+	"aoc25/Day11"
+	"aoc25/Day12"
+	"aoc25/Day2"
+	"aoc25/Day3"
+	"aoc25/Day4"
+	"aoc25/Day5"
+	"aoc25/Day6"
+	"aoc25/Day7"
+	"aoc25/Day8"
+	"aoc25/Day9"
+	"aoc25/aoc"
+)
+
+var registry = newRegistry(
+	day1.Solver{},
+	day2.Solver{},
+	day3.Solver{},
+	day4.Solver{},
+	day5.Solver{},
+	day6.Solver{},
+	day7.Solver{},
+	day8.Solver{},
+	day9.Solver{},
+	day10.Solver{},
+	day11.Solver{},
+	day12.Solver{},
+)
+
+func newRegistry(solvers ...aoc.Solver) map[int]aoc.Solver {
+	byDay := make(map[int]aoc.Solver, len(solvers))
+	for _, s := range solvers {
+		if _, dup := byDay[s.Day()]; dup {
+			panic(fmt.Sprintf("day %d registered twice", s.Day()))
+		}
+		byDay[s.Day()] = s
+	}
+	return byDay
+}
+
+// registeredDays lists every day with a solver in ascending order.
+func registeredDays() []int {
+	days := make([]int, 0, len(registry))
+	for day := range registry {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
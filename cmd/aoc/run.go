@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"aoc25/aoc"
+)
+
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	input := fs.String("input", "", "input file (only valid when running a single day)")
+	timing := fs.Bool("time", false, "report parse and per-part wall time, allocations and peak heap")
+	cpuProfile := fs.String("cpuprofile", "", "write a CPU profile of a single day to `file`")
+	memProfile := fs.String("memprofile", "", "write an allocation profile of a single day to `file`")
+	strict := fs.Bool("strict", false, "reject malformed input that days 11 and 12 would otherwise skip or repair")
+	timeout := fs.Duration("timeout", 0, "stop each day after `d`; days 10 and 12 report how far they got")
+	format := fs.String("format", formatText, "output `format`: text, json (NDJSON when running several days) or ndjson")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := validFormat(*format); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	days, err := parseDays(fs.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if *input != "" && len(days) != 1 {
+		fmt.Fprintln(stderr, "-input requires exactly one day")
+		return 2
+	}
+	if (*cpuProfile != "" || *memProfile != "") && len(days) != 1 {
+		fmt.Fprintln(stderr, "-cpuprofile and -memprofile require exactly one day")
+		return 2
+	}
+
+	if *cpuProfile != "" {
+		stopProfile, err := startCPUProfile(*cpuProfile)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer stopProfile()
+	}
+
+	if *format == formatJSON && len(days) > 1 {
+		*format = formatNDJSON
+	}
+
+	opts := runOptions{memStats: *timing, strict: *strict, timeout: *timeout}
+	status := 0
+	for _, day := range days {
+		path := aoc.DefaultInputPath(day)
+		if *input != "" {
+			path = *input
+		}
+		run := solveDay(day, path, opts)
+		if run.failed() {
+			status = 1
+		}
+
+		var err error
+		switch *format {
+		case formatJSON:
+			err = writeJSON(stdout, partRecords(run))
+		case formatNDJSON:
+			err = writeNDJSON(stdout, partRecords(run))
+		default:
+			printRun(stdout, run, *timing)
+			if runErr := run.firstErr(); runErr != nil {
+				printRunError(stderr, day, runErr)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+
+	if *memProfile != "" {
+		if err := writeMemProfile(*memProfile); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	return status
+}
+
+func printRun(w io.Writer, run dayRun, timing bool) {
+	fmt.Fprintf(w, "Day %d\n", run.day)
+	if run.err != nil {
+		return
+	}
+	for i, part := range run.parts {
+		if part.err == nil && !part.result.IsZero() {
+			fmt.Fprintf(w, "  Part %d: %s\n", i+1, part.result)
+		}
+	}
+	if !timing {
+		return
+	}
+	printStage(w, "parse", run.parse)
+	for i, part := range run.parts {
+		printStage(w, fmt.Sprintf("part %d", i+1), part.stage)
+	}
+	fmt.Fprintf(w, "  %-6s  %s\n", "peak", formatBytes(run.peakHeap))
+}
+
+// printRunError reports a failed day. Parse errors are shown compiler-style,
+// followed by the offending line with a caret under the column.
+func printRunError(w io.Writer, day int, err error) {
+	var pe *aoc.ParseError
+	if !errors.As(err, &pe) {
+		fmt.Fprintf(w, "day %d: %v\n", day, err)
+		return
+	}
+	fmt.Fprintln(w, pe)
+	if detail := pe.Detail(); detail != "" {
+		fmt.Fprintln(w, detail)
+	}
+}
+
+func printStage(w io.Writer, name string, s stage) {
+	fmt.Fprintf(w, "  %-6s  %12v  %9d allocs  %10s\n", name, roundDuration(s.duration), s.allocs, formatBytes(s.bytes))
+}
+
+// roundDuration drops precision that is only noise for longer timings.
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond)
+	}
+	return d
+}
+
+func startCPUProfile(path string) (func(), error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("start CPU profile: %w", err)
+	}
+	return func() {
+		pprof.StopCPUProfile()
+		f.Close()
+	}, nil
+}
+
+func writeMemProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create memory profile: %w", err)
+	}
+	defer f.Close()
+	runtime.GC()
+	if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+		return fmt.Errorf("write memory profile: %w", err)
+	}
+	return nil
+}